@@ -0,0 +1,93 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// Contact activity types
+const (
+	ActivitySend      = "send"
+	ActivityOpen      = "open"
+	ActivityClick     = "click"
+	ActivityGoal      = "goal"
+	ActivitySubscribe = "subscribed"
+)
+
+// ActivityFilter narrows down the activities of a contact.  The zero value returns all of them.
+type ActivityFilter struct {
+	Activity string     // one of the Activity* constants
+	From     *time.Time // only activities created on or after this day
+	To       *time.Time // only activities created on or before this day
+}
+
+func (f ActivityFilter) query(fields []string, page int32, perPage int32) url.Values {
+	query := listQuery(nil, fields, nil, page, perPage)
+	if f.Activity != "" {
+		query.Set("query[activity]", f.Activity)
+	}
+	if f.From != nil {
+		query.Set("query[createdOn][from]", f.From.Format(CustomFieldDateFormat))
+	}
+	if f.To != nil {
+		query.Set("query[createdOn][to]", f.To.Format(CustomFieldDateFormat))
+	}
+	return query
+}
+
+func (g *getResponseClient) GetContactActivities(ctx context.Context, contactID string, filter ActivityFilter, fields []string, page int32, perPage int32) ([]ContactActivity, glitch.DataError) {
+	result := make([]ContactActivity, 0)
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/contacts/%s/activities", contactID), filter.query(fields, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateContactActivities(ctx context.Context, contactID string, filter ActivityFilter, fields []string, perPage int32) *ContactActivityIterator {
+	it := &ContactActivityIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]ContactActivity, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, fmt.Sprintf("/v3/contacts/%s/activities", contactID), filter.query(fields, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+// ContactActivityIterator walks the pages of a contact's activities.  Call Next until it returns false and then check Err.
+type ContactActivityIterator struct {
+	p   *pager
+	buf []ContactActivity
+	cur ContactActivity
+}
+
+// Next advances to the next activity, fetching the next page when needed
+func (it *ContactActivityIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Activity returns the activity the iterator is positioned at
+func (it *ContactActivityIterator) Activity() ContactActivity {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ContactActivityIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *ContactActivityIterator) Pagination() Pagination {
+	return it.p.pagination
+}
@@ -0,0 +1,98 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_GetContactActivities(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		filter           ActivityFilter
+		expectedErrCode  *string
+		expectedResponse []ContactActivity
+	}
+
+	from := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if r.URL.Path != "/v3/contacts/abc/activities" || q.Get("query[activity]") != "click" || q.Get("query[createdOn][from]") != "2018-01-01" || q.Get("query[createdOn][to]") != "2018-01-31" {
+					errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+					return
+				}
+				fmt.Fprint(w, `[{"activity": "click", "subject": "Weekly digest", "createdOn": "2018-01-15T10:00:00+0000", "resource": {"resourceId": "Xy", "resourceType": "newsletters"}}]`)
+			}),
+			filter: ActivityFilter{Activity: ActivityClick, From: &from, To: &to},
+			expectedResponse: []ContactActivity{ContactActivity{
+				Activity:  ActivityClick,
+				Subject:   makeStringPtr("Weekly digest"),
+				CreatedOn: NewTimestamp(time.Date(2018, 1, 15, 10, 0, 0, 0, time.UTC)),
+				Resource:  &ActivityResource{ResourceID: "Xy", ResourceType: makeStringPtr("newsletters")},
+			}},
+		},
+		testcase{
+			name: "no filter",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("query[activity]") != "" {
+					errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+					return
+				}
+				fmt.Fprint(w, `[]`)
+			}),
+			expectedResponse: []ContactActivity{},
+		},
+		testcase{
+			name:            "unmarshal error",
+			handler:         http.HandlerFunc(undecodableHandler),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusNotFound, ErrorResourceNotFound),
+			expectedErrCode: makeStringPtr("1013"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetContactActivities(context.Background(), "abc", tc.filter, nil, 1, 10)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_IterateContactActivities(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("TotalPages", "2")
+		w.Header().Set("CurrentPage", r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `[{"activity": "open", "subject": "page %s"}]`, r.URL.Query().Get("page"))
+	}), 5*time.Second)
+	defer ts.Close()
+
+	it := c.IterateContactActivities(context.Background(), "abc", ActivityFilter{Activity: ActivityOpen}, nil, 1)
+	subjects := []string{}
+	for it.Next() {
+		subjects = append(subjects, *it.Activity().Subject)
+	}
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", it.Err())
+	}
+	if expected := []string{"page 1", "page 2"}; !reflect.DeepEqual(expected, subjects) {
+		t.Fatalf("Actual subjects (%#v) did not match expected (%#v)", subjects, expected)
+	}
+}
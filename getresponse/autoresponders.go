@@ -0,0 +1,168 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// Autoresponder trigger types
+const (
+	AutoresponderTriggerDay    = "onday"  // sent on DayOfCycle of the contact's cycle
+	AutoresponderTriggerAction = "action" // sent after the contact does Action
+)
+
+// Autoresponder trigger actions
+const (
+	AutoresponderActionSubscribe = "subscribe"
+	AutoresponderActionOpen      = "open"
+	AutoresponderActionClick     = "click"
+	AutoresponderActionGoal      = "goal"
+)
+
+// Weekdays returns the names GR expects in SelectedDays
+func Weekdays(days ...time.Weekday) []string {
+	ret := make([]string, 0, len(days))
+	for _, d := range days {
+		ret = append(ret, d.String())
+	}
+	return ret
+}
+
+// validate catches trigger settings GR would reject
+func (s *AutoresponderTriggerSettings) validate() glitch.DataError {
+	if problems := s.problems(); len(problems) > 0 {
+		return newValidationError("Invalid autoresponder trigger settings", problems...)
+	}
+	return nil
+}
+
+// problems lists what is wrong with the trigger settings
+func (s *AutoresponderTriggerSettings) problems() []string {
+	if s == nil {
+		return nil
+	}
+	problems := []string{}
+	switch s.Type {
+	case AutoresponderTriggerDay:
+		if s.DayOfCycle == nil || *s.DayOfCycle < 0 {
+			problems = append(problems, "a day trigger needs a dayOfCycle of 0 or more")
+		}
+	case AutoresponderTriggerAction:
+		if s.Action == nil {
+			problems = append(problems, "an action trigger needs an action")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown trigger type %q", s.Type))
+	}
+	if s.SendAtHour != nil && (*s.SendAtHour < 0 || *s.SendAtHour > 23) {
+		problems = append(problems, fmt.Sprintf("sendAtHour %d is not an hour of the day", *s.SendAtHour))
+	}
+	for _, day := range s.SelectedDays {
+		if !isWeekday(day) {
+			problems = append(problems, fmt.Sprintf("%q is not a day of the week", day))
+		}
+	}
+	return problems
+}
+
+func isWeekday(day string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if day == d.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *getResponseClient) GetAutoresponders(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Autoresponder, glitch.DataError) {
+	result := make([]Autoresponder, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/autoresponders", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateAutoresponders(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *AutoresponderIterator {
+	it := &AutoresponderIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Autoresponder, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/autoresponders", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetAutoresponder(ctx context.Context, ID string, fields []string) (Autoresponder, glitch.DataError) {
+	result := Autoresponder{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/autoresponders/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) CreateAutoresponder(ctx context.Context, autoresponder Autoresponder) (Autoresponder, glitch.DataError) {
+	result := Autoresponder{}
+	if err := autoresponder.TriggerSettings.validate(); err != nil {
+		return result, err
+	}
+	err := g.do(ctx, http.MethodPost, "/v3/autoresponders", nil, autoresponder, &result)
+	return result, err
+}
+
+func (g *getResponseClient) UpdateAutoresponder(ctx context.Context, ID string, newData Autoresponder) (Autoresponder, glitch.DataError) {
+	result := Autoresponder{}
+	if err := newData.TriggerSettings.validate(); err != nil {
+		return result, err
+	}
+	err := g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/autoresponders/%s", ID), nil, newData, &result)
+	return result, err
+}
+
+func (g *getResponseClient) DeleteAutoresponder(ctx context.Context, ID string) glitch.DataError {
+	return g.do(ctx, http.MethodDelete, fmt.Sprintf("/v3/autoresponders/%s", ID), nil, nil, nil)
+}
+
+func (g *getResponseClient) GetAutoresponderStatistics(ctx context.Context, ID string, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError) {
+	return g.getStatistics(ctx, fmt.Sprintf("/v3/autoresponders/%s/statistics", ID), filter)
+}
+
+func (g *getResponseClient) GetAutorespondersStatistics(ctx context.Context, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError) {
+	return g.getStatistics(ctx, "/v3/autoresponders/statistics", filter)
+}
+
+// AutoresponderIterator walks the pages of an autoresponder listing.  Call Next until it returns false and then
+// check Err.
+type AutoresponderIterator struct {
+	p   *pager
+	buf []Autoresponder
+	cur Autoresponder
+}
+
+// Next advances to the next autoresponder, fetching the next page when needed
+func (it *AutoresponderIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Autoresponder returns the autoresponder the iterator is positioned at
+func (it *AutoresponderIterator) Autoresponder() Autoresponder {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *AutoresponderIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *AutoresponderIterator) Pagination() Pagination {
+	return it.p.pagination
+}
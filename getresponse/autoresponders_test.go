@@ -0,0 +1,164 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_AutoresponderCRUD(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		call             func(c Client) (Autoresponder, error)
+		expectedMethod   string
+		expectedPath     string
+		expectedErrCode  *string
+		expectedResponse Autoresponder
+	}
+
+	ctx := context.Background()
+	onDay3 := Autoresponder{
+		Subject:    makeStringPtr("Day 3"),
+		CampaignID: makeStringPtr("V"),
+		TriggerSettings: &AutoresponderTriggerSettings{
+			Type:         AutoresponderTriggerDay,
+			DayOfCycle:   makeInt32Ptr(3),
+			SendAtHour:   makeInt32Ptr(9),
+			SelectedDays: Weekdays(time.Monday, time.Wednesday),
+		},
+	}
+	created := Autoresponder{
+		AutoresponderID: "Q",
+		Subject:         makeStringPtr("Day 3"),
+		TriggerSettings: &AutoresponderTriggerSettings{Type: AutoresponderTriggerDay, DayOfCycle: makeInt32Ptr(3), SelectedDays: []string{"Monday", "Wednesday"}},
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:             "get",
+			call:             func(c Client) (Autoresponder, error) { return c.GetAutoresponder(ctx, "Q", nil) },
+			expectedMethod:   http.MethodGet,
+			expectedPath:     "/v3/autoresponders/Q",
+			expectedResponse: created,
+		},
+		testcase{
+			name:             "create",
+			call:             func(c Client) (Autoresponder, error) { return c.CreateAutoresponder(ctx, onDay3) },
+			expectedMethod:   http.MethodPost,
+			expectedPath:     "/v3/autoresponders",
+			expectedResponse: created,
+		},
+		testcase{
+			name:             "update",
+			call:             func(c Client) (Autoresponder, error) { return c.UpdateAutoresponder(ctx, "Q", onDay3) },
+			expectedMethod:   http.MethodPost,
+			expectedPath:     "/v3/autoresponders/Q",
+			expectedResponse: created,
+		},
+		testcase{
+			name: "delete",
+			call: func(c Client) (Autoresponder, error) {
+				if err := c.DeleteAutoresponder(ctx, "Q"); err != nil {
+					return Autoresponder{}, err
+				}
+				return Autoresponder{}, nil
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/v3/autoresponders/Q",
+		},
+		testcase{
+			name: "day trigger without a day",
+			call: func(c Client) (Autoresponder, error) {
+				return c.CreateAutoresponder(ctx, Autoresponder{TriggerSettings: &AutoresponderTriggerSettings{Type: AutoresponderTriggerDay}})
+			},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name: "action trigger without an action",
+			call: func(c Client) (Autoresponder, error) {
+				return c.UpdateAutoresponder(ctx, "Q", Autoresponder{TriggerSettings: &AutoresponderTriggerSettings{Type: AutoresponderTriggerAction}})
+			},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name: "invalid send time",
+			call: func(c Client) (Autoresponder, error) {
+				return c.CreateAutoresponder(ctx, Autoresponder{TriggerSettings: &AutoresponderTriggerSettings{
+					Type:         AutoresponderTriggerAction,
+					Action:       makeStringPtr(AutoresponderActionOpen),
+					SendAtHour:   makeInt32Ptr(24),
+					SelectedDays: []string{"Funday"},
+				}})
+			},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tc.expectedMethod || r.URL.Path != tc.expectedPath {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+					errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+					return
+				}
+				if r.Method == http.MethodPost {
+					body := map[string]interface{}{}
+					b, _ := ioutil.ReadAll(r.Body)
+					json.Unmarshal(b, &body)
+					expected := map[string]interface{}{"type": "onday", "dayOfCycle": 3.0, "sendAtHour": 9.0, "selectedDays": []interface{}{"Monday", "Wednesday"}}
+					if !reflect.DeepEqual(expected, body["triggerSettings"]) {
+						t.Errorf("Unexpected trigger settings %#v", body["triggerSettings"])
+					}
+				}
+				if r.Method != http.MethodDelete {
+					fmt.Fprint(w, `{"autoresponderId": "Q", "subject": "Day 3", "triggerSettings": {"type": "onday", "dayOfCycle": 3, "selectedDays": ["Monday", "Wednesday"]}}`)
+				}
+			}), 5*time.Second)
+			defer ts.Close()
+			ret, err := tc.call(c)
+			if tc.expectedErrCode != nil {
+				if err == nil || err.(interface{ Code() string }).Code() != *tc.expectedErrCode {
+					t.Fatalf("Expected error %s, got %#v", *tc.expectedErrCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_GetAutoresponderStatistics(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/v3/autoresponders/Q/statistics" && q.Get("query[groupBy]") == "month":
+		case r.URL.Path == "/v3/autoresponders/statistics" && q.Get("query[autoresponderId]") == "Q,R":
+		default:
+			errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+			return
+		}
+		fmt.Fprint(w, testStatistics)
+	}), 5*time.Second)
+	defer ts.Close()
+
+	ret, err := c.GetAutoresponderStatistics(context.Background(), "Q", StatisticsFilter{GroupBy: GroupByMonth})
+	if err != nil || len(ret) != 2 {
+		t.Fatalf("Unexpected statistics %#v (%v)", ret, err)
+	}
+	ret, err = c.GetAutorespondersStatistics(context.Background(), StatisticsFilter{AutoresponderIDs: []string{"Q", "R"}})
+	if err != nil || SummarizeStatistics(ret).Sent != 200 {
+		t.Fatalf("Unexpected statistics %#v (%v)", ret, err)
+	}
+}
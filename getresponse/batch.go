@@ -0,0 +1,212 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// DefaultBatchConcurrency is how many operations a batch runs at once unless told otherwise
+const DefaultBatchConcurrency = 4
+
+// Batch operation kinds
+const (
+	BatchCreate             = "create"
+	BatchUpdate             = "update"
+	BatchUpdateCustomFields = "updateCustomFields"
+	BatchDelete             = "delete"
+)
+
+// BatchOperation is a contact change run by RunBatch.  The *Operation functions build them.
+type BatchOperation struct {
+	Kind         string        // BatchCreate, BatchUpdate, BatchUpdateCustomFields or BatchDelete
+	ContactID    string        // contact to update or delete
+	Contact      Contact       // contact to create or data to update it with
+	CustomFields []CustomField // values of BatchUpdateCustomFields
+	MessageID    string        // passed to DeleteContact
+	IPAddress    string        // passed to DeleteContact
+}
+
+// CreateOperation creates c like CreateContact.  Email and Campaign are required.
+func CreateOperation(c Contact) BatchOperation {
+	return BatchOperation{Kind: BatchCreate, Contact: c}
+}
+
+// UpdateOperation updates the contact like UpdateContact
+func UpdateOperation(contactID string, newData Contact) BatchOperation {
+	return BatchOperation{Kind: BatchUpdate, ContactID: contactID, Contact: newData}
+}
+
+// UpdateCustomFieldsOperation updates the contact's custom fields like UpdateContactCustomFields
+func UpdateCustomFieldsOperation(contactID string, customFields []CustomField) BatchOperation {
+	return BatchOperation{Kind: BatchUpdateCustomFields, ContactID: contactID, CustomFields: customFields}
+}
+
+// DeleteOperation deletes the contact like DeleteContact
+func DeleteOperation(contactID string) BatchOperation {
+	return BatchOperation{Kind: BatchDelete, ContactID: contactID}
+}
+
+// BatchResult is the outcome of a batch operation
+type BatchResult struct {
+	Index         int // position of the operation in the batch
+	Operation     BatchOperation
+	Contact       *Contact         // the updated contact, set by successful updates
+	Err           glitch.DataError // ErrorCanceled if the operation never ran
+	ErrorResponse *ErrorResponse   // GR's response when the API rejected the operation
+}
+
+// BatchReport has the results of a batch, in the order of its operations
+type BatchReport struct {
+	Results []BatchResult
+}
+
+// Failures returns the results of the operations that failed or never ran
+func (r BatchReport) Failures() []BatchResult {
+	ret := []BatchResult{}
+	for _, res := range r.Results {
+		if res.Err != nil {
+			ret = append(ret, res)
+		}
+	}
+	return ret
+}
+
+// Remaining returns the operations that failed or never ran, so the batch can be resumed by running them
+func (r BatchReport) Remaining() []BatchOperation {
+	ret := []BatchOperation{}
+	for _, res := range r.Failures() {
+		ret = append(ret, res.Operation)
+	}
+	return ret
+}
+
+func (g *getResponseClient) RunBatch(ctx context.Context, ops []BatchOperation, concurrency int) BatchReport {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	in := make(chan BatchOperation)
+	go func() {
+		defer close(in)
+		for _, op := range ops {
+			select {
+			case in <- op:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	report := BatchReport{Results: make([]BatchResult, len(ops))}
+	ran := make([]bool, len(ops))
+	for res := range g.RunBatchChan(ctx, in, concurrency) {
+		report.Results[res.Index] = res
+		ran[res.Index] = true
+	}
+	for i, op := range ops {
+		if !ran[i] {
+			report.Results[i] = BatchResult{Index: i, Operation: op, Err: batchCanceled(ctx)}
+		}
+	}
+	return report
+}
+
+func (g *getResponseClient) RunBatchChan(ctx context.Context, ops <-chan BatchOperation, concurrency int) <-chan BatchResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	type indexed struct {
+		i  int
+		op BatchOperation
+	}
+
+	work := make(chan indexed)
+	results := make(chan BatchResult)
+	go func() {
+		defer close(work)
+		i := 0
+		for {
+			select {
+			case op, ok := <-ops:
+				if !ok {
+					return
+				}
+				select {
+				case work <- indexed{i: i, op: op}:
+					i++
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg := sync.WaitGroup{}
+	for n := 0; n < concurrency; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for w := range work {
+				results <- g.runBatchOperation(ctx, w.i, w.op)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+func (g *getResponseClient) runBatchOperation(ctx context.Context, i int, op BatchOperation) BatchResult {
+	res := BatchResult{Index: i, Operation: op}
+	if ctx.Err() != nil {
+		res.Err = batchCanceled(ctx)
+		return res
+	}
+
+	switch op.Kind {
+	case BatchCreate:
+		c := op.Contact
+		if c.Email == nil || c.Campaign == nil {
+			res.Err = newValidationError("Invalid batch operation", "email and campaign are required")
+			break
+		}
+		res.Err = g.CreateContact(ctx, *c.Email, c.Name, c.DayOfCycle, c.Campaign.CampaignID, c.CustomFieldValues, c.IPAddress)
+	case BatchUpdate:
+		c, err := g.UpdateContact(ctx, op.ContactID, op.Contact)
+		res.Contact, res.Err = &c, err
+	case BatchUpdateCustomFields:
+		c, err := g.UpdateContactCustomFields(ctx, op.ContactID, op.CustomFields)
+		res.Contact, res.Err = &c, err
+	case BatchDelete:
+		res.Err = g.DeleteContact(ctx, op.ContactID, op.MessageID, op.IPAddress)
+	default:
+		res.Err = newValidationError("Invalid batch operation", fmt.Sprintf("unknown batch operation %q", op.Kind))
+	}
+
+	if res.Err != nil {
+		res.Contact = nil
+		var apiErr *APIError
+		if errors.As(res.Err, &apiErr) {
+			res.ErrorResponse = &apiErr.ErrorResponse
+		}
+	}
+	return res
+}
+
+func batchCanceled(ctx context.Context) glitch.DataError {
+	err := ctx.Err()
+	if err == nil {
+		err = context.Canceled
+	}
+	return glitch.NewDataError(err, ErrorCanceled, "Batch operation did not run")
+}
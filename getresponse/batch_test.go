@@ -0,0 +1,174 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUnit_RunBatch(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/gone"):
+			errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/contacts":
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPost:
+			fmt.Fprintf(w, `{"contactId": "%s"}`, r.URL.Path[len("/v3/contacts/"):])
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	ops := []BatchOperation{
+		CreateOperation(Contact{Email: makeStringPtr("a@example.com"), Campaign: &Campaign{CampaignID: "V"}}),
+		UpdateOperation("c1", Contact{Name: makeStringPtr("B")}),
+		UpdateCustomFieldsOperation("c2", []CustomField{CustomField{CustomFieldID: "cf", Value: []string{"x"}}}),
+		DeleteOperation("c3"),
+		DeleteOperation("gone"),
+		CreateOperation(Contact{Name: makeStringPtr("no email")}),
+		BatchOperation{Kind: "archive"},
+	}
+	for i := 0; i < 10; i++ {
+		ops = append(ops, DeleteOperation(fmt.Sprintf("d%d", i)))
+	}
+
+	report := c.RunBatch(context.Background(), ops, 3)
+	if len(report.Results) != len(ops) {
+		t.Fatalf("Expected %d results, got %d", len(ops), len(report.Results))
+	}
+	if maxInFlight > 3 {
+		t.Fatalf("Expected at most 3 concurrent requests, got %d", maxInFlight)
+	}
+	for i, res := range report.Results {
+		if res.Index != i || res.Operation.Kind != ops[i].Kind {
+			t.Fatalf("Result %d (%#v) is out of order", i, res)
+		}
+	}
+	if report.Results[1].Contact == nil || *report.Results[1].Contact.ContactID != "c1" {
+		t.Fatalf("Expected the updated contact, got %#v", report.Results[1])
+	}
+
+	failures := report.Failures()
+	if len(failures) != 3 {
+		t.Fatalf("Unexpected failures %#v", failures)
+	}
+	if failures[0].Index != 4 || failures[0].ErrorResponse == nil || failures[0].ErrorResponse.ErrorCode != ErrorResourceNotFound {
+		t.Fatalf("Expected the missing contact to fail with GR's error response, got %#v", failures[0])
+	}
+	for _, f := range failures[1:] {
+		if f.Err.Code() != ErrorLocalValidation || f.ErrorResponse != nil {
+			t.Fatalf("Expected a local validation failure, got %#v", f)
+		}
+	}
+	if remaining := report.Remaining(); len(remaining) != 3 || remaining[0].ContactID != "gone" {
+		t.Fatalf("Unexpected remaining operations %#v", remaining)
+	}
+}
+
+func TestUnit_RunBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	deleted := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		deleted++
+		if deleted == 2 {
+			cancel()
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	ops := []BatchOperation{}
+	for i := 0; i < 10; i++ {
+		ops = append(ops, DeleteOperation(fmt.Sprintf("d%d", i)))
+	}
+	report := c.RunBatch(ctx, ops, 1)
+
+	remaining := report.Remaining()
+	if len(remaining) == 0 || len(remaining) > 9 {
+		t.Fatalf("Expected the batch to stop part way, %d operations remain", len(remaining))
+	}
+	for _, f := range report.Failures() {
+		if f.Err.Code() != ErrorCanceled && f.Err.Code() != "ERROR_MAKING_REQUEST" {
+			t.Fatalf("Unexpected failure %#v", f)
+		}
+	}
+
+	// resuming runs only what is left
+	mu.Lock()
+	deleted = 0
+	mu.Unlock()
+	report = c.RunBatch(context.Background(), remaining, 2)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(report.Failures()) != 0 || deleted != len(remaining) {
+		t.Fatalf("Expected the resumed batch to delete the %d remaining contacts, deleted %d", len(remaining), deleted)
+	}
+}
+
+func TestUnit_RunBatchChan(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), 5*time.Second)
+	defer ts.Close()
+
+	ops := make(chan BatchOperation)
+	go func() {
+		for i := 0; i < 5; i++ {
+			ops <- DeleteOperation(fmt.Sprintf("d%d", i))
+		}
+		close(ops)
+	}()
+
+	seen := map[int]bool{}
+	for res := range c.RunBatchChan(context.Background(), ops, 0) {
+		if res.Err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", res.Err)
+		}
+		if res.Operation.ContactID != fmt.Sprintf("d%d", res.Index) {
+			t.Fatalf("Result %#v does not match its index", res)
+		}
+		seen[res.Index] = true
+	}
+	if len(seen) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(seen))
+	}
+}
+
+func TestUnit_RunBatchNilContext(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), 5*time.Second)
+	defer ts.Close()
+
+	report := c.RunBatch(nil, []BatchOperation{DeleteOperation("d1"), DeleteOperation("d2")}, 2)
+	if len(report.Failures()) != 0 || len(report.Results) != 2 {
+		t.Fatalf("Unexpected report %#v", report)
+	}
+}
@@ -0,0 +1,79 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+func (g *getResponseClient) GetCampaigns(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Campaign, glitch.DataError) {
+	result := make([]Campaign, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/campaigns", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) GetCampaign(ctx context.Context, ID string, fields []string) (Campaign, glitch.DataError) {
+	result := Campaign{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/campaigns/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) CreateCampaign(ctx context.Context, campaign Campaign) (Campaign, glitch.DataError) {
+	result := Campaign{}
+	err := g.do(ctx, http.MethodPost, "/v3/campaigns", nil, campaign, &result)
+	return result, err
+}
+
+func (g *getResponseClient) UpdateCampaign(ctx context.Context, ID string, newData Campaign) (Campaign, glitch.DataError) {
+	result := Campaign{}
+	err := g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/campaigns/%s", ID), nil, newData, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateCampaigns(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *CampaignIterator {
+	it := &CampaignIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Campaign, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/campaigns", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+// CampaignIterator walks the pages of a campaign listing.  Call Next until it returns false and then check Err.
+type CampaignIterator struct {
+	p   *pager
+	buf []Campaign
+	cur Campaign
+}
+
+// Next advances to the next campaign, fetching the next page when needed
+func (it *CampaignIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Campaign returns the campaign the iterator is positioned at
+func (it *CampaignIterator) Campaign() Campaign {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *CampaignIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *CampaignIterator) Pagination() Pagination {
+	return it.p.pagination
+}
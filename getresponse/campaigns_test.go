@@ -0,0 +1,326 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_GetCampaigns(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		timeout          time.Duration
+		ctx              context.Context
+		queryHash        map[string]string
+		fields           []string
+		sortHash         map[string]string
+		page             int32
+		perPage          int32
+		expectedErrCode  *string
+		expectedResponse []Campaign
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/campaigns" || r.URL.Query().Get("query[name]") != "customers" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `[{"campaignId": "V", "name": "customers", "languageCode": "EN"}]`)
+			}),
+			timeout:          5 * time.Second,
+			ctx:              context.Background(),
+			queryHash:        map[string]string{"name": "customers"},
+			fields:           []string{"name", "languageCode"},
+			sortHash:         map[string]string{"name": "asc"},
+			page:             1,
+			perPage:          10,
+			expectedErrCode:  nil,
+			expectedResponse: []Campaign{Campaign{CampaignID: "V", Name: "customers", LanguageCode: makeStringPtr("EN")}},
+		},
+		testcase{
+			name: "unmarshal error",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"not json"`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"code":1002}`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("1002"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, tc.timeout)
+			defer ts.Close()
+			ret, err := c.GetCampaigns(tc.ctx, tc.queryHash, tc.fields, tc.sortHash, tc.page, tc.perPage)
+			if err == nil && tc.expectedErrCode == nil {
+				if !reflect.DeepEqual(tc.expectedResponse, ret) {
+					t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+				}
+			} else {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUnit_GetCampaign(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		timeout          time.Duration
+		ctx              context.Context
+		id               string
+		fields           []string
+		expectedErrCode  *string
+		expectedResponse Campaign
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/campaigns/V" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `{"campaignId": "V", "name": "customers", "optinTypes": {"email": "double", "api": "single"}}`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			id:              "V",
+			expectedErrCode: nil,
+			expectedResponse: Campaign{
+				CampaignID: "V",
+				Name:       "customers",
+				OptinTypes: &CampaignOptinTypes{Email: makeStringPtr(OptinDouble), API: makeStringPtr(OptinSingle)},
+			},
+		},
+		testcase{
+			name: "unmarshal error",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"not json"`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"code":1013}`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("1013"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, tc.timeout)
+			defer ts.Close()
+			ret, err := c.GetCampaign(tc.ctx, tc.id, tc.fields)
+			if err == nil && tc.expectedErrCode == nil {
+				if !reflect.DeepEqual(tc.expectedResponse, ret) {
+					t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+				}
+			} else {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUnit_CreateCampaign(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		timeout          time.Duration
+		ctx              context.Context
+		campaign         Campaign
+		expectedErrCode  *string
+		expectedResponse Campaign
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := map[string]interface{}{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if _, ok := body["campaignId"]; ok || body["name"] != "customer_42" {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"code":1000}`)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"campaignId": "V", "name": "customer_42", "languageCode": "EN"}`)
+			}),
+			timeout:          5 * time.Second,
+			ctx:              context.Background(),
+			campaign:         Campaign{Name: "customer_42", LanguageCode: makeStringPtr("EN")},
+			expectedErrCode:  nil,
+			expectedResponse: Campaign{CampaignID: "V", Name: "customer_42", LanguageCode: makeStringPtr("EN")},
+		},
+		testcase{
+			name: "unmarshal error",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"not json"`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"code":1008}`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("1008"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, tc.timeout)
+			defer ts.Close()
+			ret, err := c.CreateCampaign(tc.ctx, tc.campaign)
+			if err == nil && tc.expectedErrCode == nil {
+				if !reflect.DeepEqual(tc.expectedResponse, ret) {
+					t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+				}
+			} else {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUnit_UpdateCampaign(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		timeout          time.Duration
+		ctx              context.Context
+		id               string
+		newData          Campaign
+		expectedErrCode  *string
+		expectedResponse Campaign
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v3/campaigns/V" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `{"campaignId": "V", "name": "customers", "postal": {"city": "Gdansk"}}`)
+			}),
+			timeout:          5 * time.Second,
+			ctx:              context.Background(),
+			id:               "V",
+			newData:          Campaign{Postal: &CampaignPostal{City: makeStringPtr("Gdansk")}},
+			expectedErrCode:  nil,
+			expectedResponse: Campaign{CampaignID: "V", Name: "customers", Postal: &CampaignPostal{City: makeStringPtr("Gdansk")}},
+		},
+		testcase{
+			name: "unmarshal error",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"not json"`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"code":1000}`)
+			}),
+			timeout:         5 * time.Second,
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr("1000"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, tc.timeout)
+			defer ts.Close()
+			ret, err := c.UpdateCampaign(tc.ctx, tc.id, tc.newData)
+			if err == nil && tc.expectedErrCode == nil {
+				if !reflect.DeepEqual(tc.expectedResponse, ret) {
+					t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+				}
+			} else {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}
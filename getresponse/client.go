@@ -4,10 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/healthimation/go-client/client"
@@ -16,7 +15,17 @@ import (
 
 //Error codes
 const (
-	ErrorAPI = "ERROR_API"
+	ErrorAPI      = "ERROR_API"
+	ErrorCanceled = "ERROR_CANCELED"
+	ErrorOAuth    = "ERROR_OAUTH"
+	ErrorTimeout  = "ERROR_TIMEOUT"
+
+	ErrorImportRejected = "ERROR_IMPORT_REJECTED"
+	ErrorCSV            = "ERROR_CSV"
+
+	ErrorFromFieldNotVerified = "ERROR_FROM_FIELD_NOT_VERIFIED"
+
+	ErrorLocalValidation = "ERROR_LOCAL_VALIDATION"
 
 	// described @ https://apidocs.getresponse.com/v3/errors
 	ErrorInternalError           = 1
@@ -47,9 +56,24 @@ type Client interface {
 	// CreateContact - https://apidocs.getresponse.com/v3/resources/contacts#contacts.create
 	CreateContact(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, ipAddress *string) glitch.DataError
 
+	// CreateContactAsync is CreateContact returning a handle to wait for GR to actually create the contact
+	CreateContactAsync(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, ipAddress *string) (*PendingContact, glitch.DataError)
+
+	// CreateContactAndWait is CreateContact waiting, according to the client's PollPolicy, for the contact to be created
+	CreateContactAndWait(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, ipAddress *string) (Contact, glitch.DataError)
+
 	// GetContacts - https://apidocs.getresponse.com/v3/resources/contacts#contacts.get.all
 	GetContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32, additionalFlags *string) ([]Contact, glitch.DataError)
 
+	// IterateContacts walks every page of GetContacts, fetching pages lazily as the iterator advances
+	IterateContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32, additionalFlags *string) *ContactIterator
+
+	// QueryContacts is GetContacts filtered and sorted by a ContactQuery, which is checked before anything is sent
+	QueryContacts(ctx context.Context, q *ContactQuery, fields []string, page int32, perPage int32, additionalFlags *string) ([]Contact, glitch.DataError)
+
+	// IterateContactQuery walks every page of QueryContacts, fetching pages lazily as the iterator advances
+	IterateContactQuery(ctx context.Context, q *ContactQuery, fields []string, perPage int32, additionalFlags *string) *ContactIterator
+
 	// Get Contact - https://apidocs.getresponse.com/v3/resources/contacts#contacts.get
 	GetContact(ctx context.Context, ID string, fields []string) (Contact, glitch.DataError)
 
@@ -61,211 +85,404 @@ type Client interface {
 
 	// DeleteContact - https://apidocs.getresponse.com/v3/resources/contacts#contacts.delete
 	DeleteContact(ctx context.Context, ID string, messageID string, ipAddress string) glitch.DataError
-}
 
-type getResponseClient struct {
-	c      client.BaseClient
-	apiKey string
-}
+	// GetContactActivities - https://apidocs.getresponse.com/v3/resources/contacts#contacts.activities
+	GetContactActivities(ctx context.Context, contactID string, filter ActivityFilter, fields []string, page int32, perPage int32) ([]ContactActivity, glitch.DataError)
 
-// NewClient returns a new pushy client
-func NewClient(apiKey string, timeout time.Duration) Client {
-	return &getResponseClient{
-		c:      client.NewBaseClient(findGetResponse, "getresponse", true, timeout),
-		apiKey: apiKey,
-	}
-}
+	// IterateContactActivities walks every page of GetContactActivities, fetching pages lazily as the iterator advances
+	IterateContactActivities(ctx context.Context, contactID string, filter ActivityFilter, fields []string, perPage int32) *ContactActivityIterator
 
-func (g *getResponseClient) CreateContact(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, ipAddress *string) glitch.DataError {
-	slug := "/v3/contacts"
-	h := http.Header{}
-	h.Set("Content-type", "application/json")
-	h.Set("X-Auth-Token", fmt.Sprintf("api-key %s", g.apiKey))
-
-	bodyObj := createContactRequest{
-		Email:             email,
-		Name:              name,
-		DayOfCycle:        dayOfCycle,
-		Campaign:          Campaign{CampaignID: campaignID},
-		CustomFieldValues: customFields,
-		IPAddress:         ipAddress,
-	}
+	// UpsertContact makes sure email is in the campaign: it updates the contact if it exists, merging customFields and
+	// tags into the ones it already has, and creates it otherwise.  When a concurrent create wins the race the contact
+	// is waited for and merged; the 1008 error is only returned if it doesn't show up in time.
+	UpsertContact(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, tags []Tag, ipAddress *string) (Contact, glitch.DataError)
 
-	body, err := client.ObjectToJSONReader(bodyObj)
-	if err != nil {
-		return err
-	}
+	// GetCampaigns - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.get.all
+	GetCampaigns(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Campaign, glitch.DataError)
 
-	status, ret, err := g.c.MakeRequest(ctx, http.MethodPost, slug, nil, h, body)
-	if err != nil {
-		return err
-	}
+	// IterateCampaigns walks every page of GetCampaigns, fetching pages lazily as the iterator advances
+	IterateCampaigns(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *CampaignIterator
 
-	if status < 200 || status >= 400 {
-		//parse error
-		return g.parseError(ret)
-	}
+	// GetCampaign - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.get
+	GetCampaign(ctx context.Context, ID string, fields []string) (Campaign, glitch.DataError)
 
-	return nil
-}
+	// CreateCampaign - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.create
+	CreateCampaign(ctx context.Context, campaign Campaign) (Campaign, glitch.DataError)
 
-func (g *getResponseClient) GetContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32, additionalFlags *string) ([]Contact, glitch.DataError) {
-	slug := "/v3/contacts"
-	h := http.Header{}
-	h.Set("Content-type", "application/json")
-	h.Set("X-Auth-Token", fmt.Sprintf("api-key %s", g.apiKey))
+	// UpdateCampaign - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.update
+	UpdateCampaign(ctx context.Context, ID string, newData Campaign) (Campaign, glitch.DataError)
 
-	query := url.Values{}
-	for k, v := range queryHash {
-		query.Set(fmt.Sprintf("query[%s]", k), v)
-	}
+	// GetCustomFields - https://apidocs.getresponse.com/v3/resources/customfields#customfields.get.all
+	GetCustomFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]CustomFieldDefinition, glitch.DataError)
 
-	for k, v := range sortHash {
-		query.Set(fmt.Sprintf("sort[%s]", k), v)
-	}
+	// IterateCustomFields walks every page of GetCustomFields, fetching pages lazily as the iterator advances
+	IterateCustomFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *CustomFieldIterator
 
-	if len(fields) > 0 {
-		query.Set("fields", strings.Join(fields, ","))
-	}
+	// GetCustomField - https://apidocs.getresponse.com/v3/resources/customfields#customfields.get
+	GetCustomField(ctx context.Context, ID string, fields []string) (CustomFieldDefinition, glitch.DataError)
 
-	query.Set("page", strconv.Itoa(int(page)))
-	query.Set("perPage", strconv.Itoa(int(perPage)))
+	// GetCustomFieldByName returns the custom field with exactly this name, or an error matching ErrResourceNotFound
+	GetCustomFieldByName(ctx context.Context, name string) (CustomFieldDefinition, glitch.DataError)
 
-	if additionalFlags != nil {
-		query.Set("additionalFlags", *additionalFlags)
-	}
+	// CreateCustomField - https://apidocs.getresponse.com/v3/resources/customfields#customfields.create
+	CreateCustomField(ctx context.Context, definition CustomFieldDefinition) (CustomFieldDefinition, glitch.DataError)
 
-	result := make([]Contact, 0)
-	status, ret, err := g.c.MakeRequest(ctx, http.MethodGet, slug, query, h, nil)
-	if err != nil {
-		return result, err
-	}
+	// UpdateCustomField - https://apidocs.getresponse.com/v3/resources/customfields#customfields.update
+	UpdateCustomField(ctx context.Context, ID string, newData CustomFieldDefinition) (CustomFieldDefinition, glitch.DataError)
 
-	if status < 200 || status >= 400 {
-		//parse error
-		return result, g.parseError(ret)
-	}
+	// DeleteCustomField - https://apidocs.getresponse.com/v3/resources/customfields#customfields.delete
+	DeleteCustomField(ctx context.Context, ID string) glitch.DataError
 
-	jErr := json.Unmarshal(ret, &result)
-	if jErr != nil {
-		return result, glitch.NewDataError(err, client.ErrorDecodingResponse, fmt.Sprintf("Could not unmarshal response: %s", ret))
-	}
+	// GetTags - https://apidocs.getresponse.com/v3/resources/tags#tags.get.all
+	GetTags(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Tag, glitch.DataError)
 
-	return result, nil
-}
+	// IterateTags walks every page of GetTags, fetching pages lazily as the iterator advances
+	IterateTags(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *TagIterator
 
-func (g *getResponseClient) GetContact(ctx context.Context, ID string, fields []string) (Contact, glitch.DataError) {
-	slug := fmt.Sprintf("/v3/contacts/%s", ID)
-	h := http.Header{}
-	h.Set("Content-type", "application/json")
-	h.Set("X-Auth-Token", fmt.Sprintf("api-key %s", g.apiKey))
+	// GetTag - https://apidocs.getresponse.com/v3/resources/tags#tags.get
+	GetTag(ctx context.Context, ID string, fields []string) (Tag, glitch.DataError)
 
-	query := url.Values{}
-	if len(fields) > 0 {
-		query.Set("fields", strings.Join(fields, ","))
-	}
+	// CreateTag - https://apidocs.getresponse.com/v3/resources/tags#tags.create
+	CreateTag(ctx context.Context, tag Tag) (Tag, glitch.DataError)
 
-	result := Contact{}
-	status, ret, err := g.c.MakeRequest(ctx, http.MethodGet, slug, query, h, nil)
-	if err != nil {
-		return result, err
-	}
+	// UpdateTag - https://apidocs.getresponse.com/v3/resources/tags#tags.update
+	UpdateTag(ctx context.Context, ID string, newData Tag) (Tag, glitch.DataError)
 
-	if status < 200 || status >= 400 {
-		//parse error
-		return result, g.parseError(ret)
-	}
+	// DeleteTag - https://apidocs.getresponse.com/v3/resources/tags#tags.delete
+	DeleteTag(ctx context.Context, ID string) glitch.DataError
 
-	jErr := json.Unmarshal(ret, &result)
-	if jErr != nil {
-		return result, glitch.NewDataError(err, client.ErrorDecodingResponse, fmt.Sprintf("Could not unmarshal response: %s", ret))
-	}
+	// ResolveTags returns the tags with these names, in order.  Missing tags are created if create is true, otherwise
+	// they are reported with an error matching ErrResourceNotFound.
+	ResolveTags(ctx context.Context, names []string, create bool) ([]Tag, glitch.DataError)
+
+	// AddContactTags - https://apidocs.getresponse.com/v3/resources/contacts#contacts.upsert.tags
+	AddContactTags(ctx context.Context, contactID string, tags []Tag) glitch.DataError
+
+	// AddContactTagsByName is AddContactTags for tag names, creating the tags that don't exist yet
+	AddContactTagsByName(ctx context.Context, contactID string, names []string) glitch.DataError
+
+	// RemoveContactTags removes the tags from the contact, keeping its other tags
+	RemoveContactTags(ctx context.Context, contactID string, tags []Tag) glitch.DataError
+
+	// GetSearchContacts - https://apidocs.getresponse.com/v3/resources/search-contacts#search-contacts.get.all
+	GetSearchContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]SearchContact, glitch.DataError)
+
+	// IterateSearchContacts walks every page of GetSearchContacts, fetching pages lazily as the iterator advances
+	IterateSearchContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *SearchContactIterator
+
+	// GetSearchContact - https://apidocs.getresponse.com/v3/resources/search-contacts#search-contacts.get
+	GetSearchContact(ctx context.Context, ID string, fields []string) (SearchContact, glitch.DataError)
+
+	// CreateSearchContact - https://apidocs.getresponse.com/v3/resources/search-contacts#search-contacts.create
+	CreateSearchContact(ctx context.Context, search SearchContact) (SearchContact, glitch.DataError)
+
+	// UpdateSearchContact - https://apidocs.getresponse.com/v3/resources/search-contacts#search-contacts.update
+	UpdateSearchContact(ctx context.Context, ID string, newData SearchContact) (SearchContact, glitch.DataError)
+
+	// DeleteSearchContact - https://apidocs.getresponse.com/v3/resources/search-contacts#search-contacts.delete
+	DeleteSearchContact(ctx context.Context, ID string) glitch.DataError
+
+	// GetSearchContactsContacts - https://apidocs.getresponse.com/v3/resources/search-contacts#search-contacts.contacts.get.all
+	GetSearchContactsContacts(ctx context.Context, ID string, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Contact, glitch.DataError)
+
+	// IterateSearchContactsContacts walks every page of GetSearchContactsContacts, fetching pages lazily as the iterator advances
+	IterateSearchContactsContacts(ctx context.Context, ID string, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *ContactIterator
+
+	// SearchContactsByConditions - https://apidocs.getresponse.com/v3/resources/search-contacts#search-contacts.contacts.search
+	SearchContactsByConditions(ctx context.Context, conditions SearchContactsConditions, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Contact, glitch.DataError)
+
+	// IterateSearchContactsByConditions walks every page of SearchContactsByConditions, fetching pages lazily as the iterator advances
+	IterateSearchContactsByConditions(ctx context.Context, conditions SearchContactsConditions, fields []string, sortHash map[string]string, perPage int32) *ContactIterator
+
+	// GetImports - https://apidocs.getresponse.com/v3/resources/imports#imports.get.all
+	GetImports(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Import, glitch.DataError)
+
+	// IterateImports walks every page of GetImports, fetching pages lazily as the iterator advances
+	IterateImports(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *ImportIterator
+
+	// GetImport - https://apidocs.getresponse.com/v3/resources/imports#imports.get
+	GetImport(ctx context.Context, ID string, fields []string) (Import, glitch.DataError)
+
+	// CreateImport - https://apidocs.getresponse.com/v3/resources/imports#imports.create
+	// At most MaxImportContacts contacts can be imported at once.  Only email, name and custom fields are imported.
+	CreateImport(ctx context.Context, campaignID string, contacts []Contact) (Import, glitch.DataError)
+
+	// WaitForImport polls, according to the client's PollPolicy, until GR has finished or rejected the import
+	WaitForImport(ctx context.Context, ID string) (Import, glitch.DataError)
+
+	// ImportContacts imports every contact of source into the campaign, creating as many imports as needed.  Rows
+	// which can't be sent or whose import couldn't be created are reported as failures rather than stopping the
+	// import; the error is only set when the source fails or the context is done.  Every row read from the source
+	// ends up in an import or a failure of the report.
+	ImportContacts(ctx context.Context, campaignID string, source ContactSource) (ImportReport, glitch.DataError)
+
+	// ImportContactsAndWait is ImportContacts waiting for every import to finish, adding the rows of rejected
+	// imports to the failures
+	ImportContactsAndWait(ctx context.Context, campaignID string, source ContactSource) (ImportReport, glitch.DataError)
+
+	// ExportContactsCSV writes the contacts matching queryHash to w as a CSV with a header row of columns, which are
+	// CSVColumn* constants or custom field names.  Tags and multiple values are separated by CSVValueSeparator.  It
+	// returns the number of contacts written.
+	ExportContactsCSV(ctx context.Context, w io.Writer, columns []string, queryHash map[string]string) (int, glitch.DataError)
+
+	// ImportContactsCSV creates, or with opts.Upsert upserts, a contact for every row of a CSV in the format
+	// ExportContactsCSV writes.  A row which fails doesn't stop the import, check the report for failures.
+	ImportContactsCSV(ctx context.Context, r io.Reader, opts CSVImportOptions) (CSVImportReport, glitch.DataError)
+
+	// RunBatch runs the contact operations, at most concurrency at a time, and reports the outcome of each.  Every
+	// request goes through the client's rate limiter and retry policy.  Resume a batch that failed part way by
+	// running the report's Remaining operations.
+	RunBatch(ctx context.Context, ops []BatchOperation, concurrency int) BatchReport
+
+	// RunBatchChan is RunBatch for operations streamed over a channel.  Results arrive as operations finish, out of
+	// order, and the channel is closed once ops is closed or the context is done and the running operations finish.
+	// Read the results until the channel is closed.
+	RunBatchChan(ctx context.Context, ops <-chan BatchOperation, concurrency int) <-chan BatchResult
+
+	// GetNewsletters - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.get.all
+	GetNewsletters(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Newsletter, glitch.DataError)
+
+	// IterateNewsletters walks every page of GetNewsletters, fetching pages lazily as the iterator advances
+	IterateNewsletters(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *NewsletterIterator
+
+	// GetNewsletter - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.get
+	GetNewsletter(ctx context.Context, ID string, fields []string) (Newsletter, glitch.DataError)
 
-	return result, nil
+	// CreateNewsletter - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.create
+	// A newsletter of type NewsletterTypeBroadcast is sent straight away, or at SendOn when set.
+	CreateNewsletter(ctx context.Context, newsletter Newsletter) (Newsletter, glitch.DataError)
+
+	// SendNewsletterDraft - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.send-draft
+	SendNewsletterDraft(ctx context.Context, ID string, sendOn *time.Time, sendSettings NewsletterSendSettings) (Newsletter, glitch.DataError)
+
+	// DeleteNewsletter - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.delete
+	// A newsletter being sent can't be deleted, the error then matches ErrMessageAlreadySending.
+	DeleteNewsletter(ctx context.Context, ID string) glitch.DataError
+
+	// CancelNewsletter - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.cancel
+	// A newsletter being sent can't be canceled, the error then matches ErrMessageAlreadySending.
+	CancelNewsletter(ctx context.Context, ID string) (Newsletter, glitch.DataError)
+
+	// GetNewsletterStatistics - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.statistics.get
+	GetNewsletterStatistics(ctx context.Context, ID string, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError)
+
+	// GetNewslettersStatistics - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.statistics.get.all
+	GetNewslettersStatistics(ctx context.Context, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError)
+
+	// GetAutoresponders - https://apidocs.getresponse.com/v3/resources/autoresponders#autoresponders.get.all
+	GetAutoresponders(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Autoresponder, glitch.DataError)
+
+	// IterateAutoresponders walks every page of GetAutoresponders, fetching pages lazily as the iterator advances
+	IterateAutoresponders(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *AutoresponderIterator
+
+	// GetAutoresponder - https://apidocs.getresponse.com/v3/resources/autoresponders#autoresponders.get
+	GetAutoresponder(ctx context.Context, ID string, fields []string) (Autoresponder, glitch.DataError)
+
+	// CreateAutoresponder - https://apidocs.getresponse.com/v3/resources/autoresponders#autoresponders.create
+	CreateAutoresponder(ctx context.Context, autoresponder Autoresponder) (Autoresponder, glitch.DataError)
+
+	// UpdateAutoresponder - https://apidocs.getresponse.com/v3/resources/autoresponders#autoresponders.update
+	UpdateAutoresponder(ctx context.Context, ID string, newData Autoresponder) (Autoresponder, glitch.DataError)
+
+	// DeleteAutoresponder - https://apidocs.getresponse.com/v3/resources/autoresponders#autoresponders.delete
+	DeleteAutoresponder(ctx context.Context, ID string) glitch.DataError
+
+	// GetAutoresponderStatistics - https://apidocs.getresponse.com/v3/resources/autoresponders#autoresponders.statistics.get
+	GetAutoresponderStatistics(ctx context.Context, ID string, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError)
+
+	// GetAutorespondersStatistics - https://apidocs.getresponse.com/v3/resources/autoresponders#autoresponders.statistics.get.all
+	GetAutorespondersStatistics(ctx context.Context, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError)
+
+	// GetFromFields - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.get.all
+	GetFromFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]FromField, glitch.DataError)
+
+	// IterateFromFields walks every page of GetFromFields, fetching pages lazily as the iterator advances
+	IterateFromFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *FromFieldIterator
+
+	// GetFromField - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.get
+	GetFromField(ctx context.Context, ID string, fields []string) (FromField, glitch.DataError)
+
+	// CreateFromField - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.create
+	// GR sends a verification email to the address, the from field can't be used until it is followed.
+	CreateFromField(ctx context.Context, fromField FromField) (FromField, glitch.DataError)
+
+	// DeleteFromField - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.delete
+	// Messages and campaigns using the from field are moved to replacementID, if given.
+	DeleteFromField(ctx context.Context, ID string, replacementID string) glitch.DataError
+
+	// SetDefaultFromField - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.default
+	SetDefaultFromField(ctx context.Context, ID string) (FromField, glitch.DataError)
+
+	// VerifiedFromField returns the from field if it has been verified, and otherwise an error with the code
+	// ErrorFromFieldNotVerified, so a send can be checked before it is scheduled
+	VerifiedFromField(ctx context.Context, ID string) (FromField, glitch.DataError)
 }
 
-func (g *getResponseClient) UpdateContact(ctx context.Context, ID string, newData Contact) (Contact, glitch.DataError) {
-	result := Contact{}
-	slug := fmt.Sprintf("/v3/contacts/%s", ID)
-	h := http.Header{}
-	h.Set("Content-type", "application/json")
-	h.Set("X-Auth-Token", fmt.Sprintf("api-key %s", g.apiKey))
+type getResponseClient struct {
+	finder      client.ServiceFinder
+	httpClient  *http.Client
+	timeout     time.Duration
+	apiKey      string
+	tokenSource TokenSource
+	domain      string
+	userAgent   string
+	retry       RetryPolicy
+	pollPolicy  PollPolicy
+	importSize  int
+	validate    bool
+	cfCache     customFieldCache
+	limiter     *RateLimiter
+	logger      Logger
+	hooks       Hooks
+}
 
-	body, err := client.ObjectToJSONReader(newData)
-	if err != nil {
-		return result, err
-	}
+// NewClient returns a new pushy client
+func NewClient(apiKey string, timeout time.Duration, opts ...Option) Client {
+	return NewClientWithOptions(append([]Option{WithAPIKey(apiKey), WithTimeout(timeout)}, opts...)...)
+}
 
-	status, ret, err := g.c.MakeRequest(ctx, http.MethodPost, slug, nil, h, body)
-	if err != nil {
-		return result, err
+// NewClientWithOptions returns a new client configured by opts.  Without options it talks to
+// https://api.getresponse.com/ using http.DefaultTransport and never retries.
+func NewClientWithOptions(opts ...Option) Client {
+	g := &getResponseClient{
+		finder:     findGetResponse,
+		httpClient: &http.Client{},
+		userAgent:  DefaultUserAgent,
+		pollPolicy: DefaultPollPolicy,
+		importSize: MaxImportContacts,
 	}
-
-	if status < 200 || status >= 400 {
-		//parse error
-		return result, g.parseError(ret)
+	for _, opt := range opts {
+		opt(g)
 	}
-
-	jErr := json.Unmarshal(ret, &result)
-	if jErr != nil {
-		return result, glitch.NewDataError(err, client.ErrorDecodingResponse, fmt.Sprintf("Could not unmarshal response: %s", ret))
+	if g.timeout > 0 {
+		// don't modify a client the caller may share with other code
+		hc := *g.httpClient
+		hc.Timeout = g.timeout
+		g.httpClient = &hc
 	}
+	return g
+}
 
-	return result, nil
+func (g *getResponseClient) CreateContact(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, ipAddress *string) glitch.DataError {
+	_, err := g.createContactWithTags(ctx, email, name, dayOfCycle, campaignID, customFields, nil, ipAddress)
+	return err
 }
 
-func (g *getResponseClient) UpdateContactCustomFields(ctx context.Context, ID string, customFields []CustomField) (Contact, glitch.DataError) {
+func (g *getResponseClient) GetContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32, additionalFlags *string) ([]Contact, glitch.DataError) {
+	result := make([]Contact, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/contacts", contactsQuery(queryHash, fields, sortHash, page, perPage, additionalFlags), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32, additionalFlags *string) *ContactIterator {
+	it := &ContactIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Contact, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/contacts", contactsQuery(queryHash, fields, sortHash, page, perPage, additionalFlags), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetContact(ctx context.Context, ID string, fields []string) (Contact, glitch.DataError) {
 	result := Contact{}
-	slug := fmt.Sprintf("/v3/contacts/%s/custom-fields", ID)
-	h := http.Header{}
-	h.Set("Content-type", "application/json")
-	h.Set("X-Auth-Token", fmt.Sprintf("api-key %s", g.apiKey))
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/contacts/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
 
-	bodyObj := updateCustomFieldRequest{customFields}
-	body, err := client.ObjectToJSONReader(bodyObj)
+func (g *getResponseClient) UpdateContact(ctx context.Context, ID string, newData Contact) (Contact, glitch.DataError) {
+	result := Contact{}
+	customFields, err := g.encodeCustomFields(ctx, newData.CustomFieldValues)
 	if err != nil {
 		return result, err
 	}
+	newData.CustomFieldValues = customFields
+
+	err = g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/contacts/%s", ID), nil, newData, &result)
+	return result, err
+}
 
-	status, ret, err := g.c.MakeRequest(ctx, http.MethodPost, slug, nil, h, body)
+func (g *getResponseClient) UpdateContactCustomFields(ctx context.Context, ID string, customFields []CustomField) (Contact, glitch.DataError) {
+	result := Contact{}
+	customFields, err := g.encodeCustomFields(ctx, customFields)
 	if err != nil {
 		return result, err
 	}
 
-	if status < 200 || status >= 400 {
-		//parse error
-		return result, g.parseError(ret)
-	}
-
-	jErr := json.Unmarshal(ret, &result)
-	if jErr != nil {
-		return result, glitch.NewDataError(err, client.ErrorDecodingResponse, fmt.Sprintf("Could not unmarshal response: %s", ret))
-	}
-
-	return result, nil
+	bodyObj := updateCustomFieldRequest{customFields}
+	err = g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/contacts/%s/custom-fields", ID), nil, bodyObj, &result)
+	return result, err
 }
 
 func (g *getResponseClient) DeleteContact(ctx context.Context, ID string, messageID string, ipAddress string) glitch.DataError {
-	slug := fmt.Sprintf("/v3/contacts/%s", ID)
-	h := http.Header{}
-	h.Set("Content-type", "application/json")
-	h.Set("X-Auth-Token", fmt.Sprintf("api-key %s", g.apiKey))
-
 	query := url.Values{}
 	query.Set("messageId", messageID)
 	query.Set("ipAddress", ipAddress)
 
-	status, ret, err := g.c.MakeRequest(ctx, http.MethodDelete, slug, query, h, nil)
-	if err != nil {
-		return err
+	return g.do(ctx, http.MethodDelete, fmt.Sprintf("/v3/contacts/%s", ID), query, nil, nil)
+}
+
+func contactsQuery(queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32, additionalFlags *string) url.Values {
+	query := listQuery(queryHash, fields, sortHash, page, perPage)
+	if additionalFlags != nil {
+		query.Set("additionalFlags", *additionalFlags)
 	}
+	return query
+}
+
+// ContactIterator walks the pages of a contact listing.  Call Next until it returns false and then check Err.
+type ContactIterator struct {
+	p   *pager
+	buf []Contact
+	cur Contact
+}
 
-	if status < 200 || status >= 400 {
-		//parse error
-		return g.parseError(ret)
+// Next advances to the next contact, fetching the next page when needed.  It returns false when the listing is
+// exhausted, the context is done or a request failed.
+func (it *ContactIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
 	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Contact returns the contact the iterator is positioned at
+func (it *ContactIterator) Contact() Contact {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ContactIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *ContactIterator) Pagination() Pagination {
+	return it.p.pagination
+}
 
-	return nil
+// Chan streams the remaining contacts over a channel which is closed when the iteration stops; check Err afterwards.
+// Cancel the iterator's context to stop the feeding goroutine early.
+func (it *ContactIterator) Chan() <-chan Contact {
+	ch := make(chan Contact)
+	go func() {
+		defer close(ch)
+		for it.Next() {
+			select {
+			case ch <- it.Contact():
+			case <-it.p.ctx.Done():
+				it.p.alive()
+				return
+			}
+		}
+	}()
+	return ch
 }
 
 func (g *getResponseClient) parseError(resp []byte) glitch.DataError {
@@ -274,5 +491,5 @@ func (g *getResponseClient) parseError(resp []byte) glitch.DataError {
 	if err != nil {
 		return glitch.NewDataError(err, client.ErrorDecodingError, fmt.Sprintf("Could not unmarshal error response: %s", resp))
 	}
-	return glitch.NewDataError(nil, fmt.Sprintf("%d", errRet.ErrorCode), fmt.Sprintf("%s | context: %s", errRet.Message, strings.Join(errRet.Context, ", ")))
+	return &APIError{ErrorResponse: errRet}
 }
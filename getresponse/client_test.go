@@ -2,31 +2,21 @@ package getresponse
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"testing"
 	"time"
 
 	"reflect"
 
-	"github.com/healthimation/go-client/client"
+	"github.com/healthimation/go-glitch/glitch"
 )
 
 func testClient(handler http.HandlerFunc, timeout time.Duration) (Client, *httptest.Server) {
 	ts := httptest.NewServer(handler)
-	finder := func(serviceName string, useTLS bool) (url.URL, error) {
-		ret, err := url.Parse(ts.URL)
-		if err != nil || ret == nil {
-			return url.URL{}, err
-		}
-		return *ret, err
-	}
-	c := &getResponseClient{
-		c:      client.NewBaseClient(finder, "gr", true, timeout),
-		apiKey: "",
-	}
+	c := NewClientWithOptions(WithBaseURL(ts.URL), WithTimeout(timeout))
 	return c, ts
 }
 
@@ -37,6 +27,39 @@ func makeStringPtr(v string) *string {
 	return &v
 }
 
+// checkDataError fails the test if err doesn't match expectedErrCode and reports whether the call succeeded
+func checkDataError(t *testing.T, err glitch.DataError, expectedErrCode *string) bool {
+	if err == nil && expectedErrCode == nil {
+		return true
+	}
+	if expectedErrCode == nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if err == nil {
+		t.Fatalf("Expected error did not occur")
+	}
+	if err.Code() != *expectedErrCode {
+		t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *expectedErrCode)
+	}
+	var validationErr *ValidationError
+	if err.Code() == ErrorLocalValidation && !errors.As(err, &validationErr) {
+		t.Fatalf("Local validation error (%#v) is not a *ValidationError", err)
+	}
+	return false
+}
+
+func errorHandler(status int, code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"httpStatus": %d, "code": %d}`, status, code)
+	}
+}
+
+func undecodableHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusInternalServerError)
+	fmt.Fprint(w, `{"not json"`)
+}
+
 func TestUnit_CreateContact(t *testing.T) {
 
 	type testcase struct {
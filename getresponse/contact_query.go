@@ -0,0 +1,215 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// SortDirection orders a sorted listing
+type SortDirection string
+
+// Sort directions
+const (
+	Asc  SortDirection = "asc"
+	Desc SortDirection = "desc"
+)
+
+// ContactSortField is a field contact listings can be sorted by
+type ContactSortField string
+
+// Contact sort fields
+const (
+	SortByEmail      ContactSortField = "email"
+	SortByName       ContactSortField = "name"
+	SortByCampaignID ContactSortField = "campaignId"
+	SortByCreatedOn  ContactSortField = "createdOn"
+	SortByChangedOn  ContactSortField = "changedOn"
+)
+
+type contactSort struct {
+	field     ContactSortField
+	direction SortDirection
+}
+
+// ContactQuery builds the query[...] and sort[...] parameters of a contact listing, catching mistakes before the
+// request is sent:
+//
+//	q := getresponse.NewContactQuery().CampaignID("V").CreatedFrom(since).SortBy(getresponse.SortByCreatedOn, getresponse.Desc)
+type ContactQuery struct {
+	query       url.Values
+	createdFrom *time.Time
+	createdTo   *time.Time
+	changedFrom *time.Time
+	changedTo   *time.Time
+	sort        *contactSort
+	problems    []string
+}
+
+// NewContactQuery returns an empty query, which matches every contact
+func NewContactQuery() *ContactQuery {
+	return &ContactQuery{query: url.Values{}}
+}
+
+// Email matches contacts whose email contains email
+func (q *ContactQuery) Email(email string) *ContactQuery {
+	return q.set("email", email)
+}
+
+// Name matches contacts whose name contains name
+func (q *ContactQuery) Name(name string) *ContactQuery {
+	return q.set("name", name)
+}
+
+// CampaignID matches contacts in the campaign
+func (q *ContactQuery) CampaignID(campaignID string) *ContactQuery {
+	return q.set("campaignId", campaignID)
+}
+
+// Origin matches contacts by how they subscribed, e.g. "api" or "import"
+func (q *ContactQuery) Origin(origin string) *ContactQuery {
+	return q.set("origin", origin)
+}
+
+// CreatedFrom matches contacts created on or after the day of t
+func (q *ContactQuery) CreatedFrom(t time.Time) *ContactQuery {
+	return q.setTime(&q.createdFrom, "createdOn from", t)
+}
+
+// CreatedTo matches contacts created on or before the day of t
+func (q *ContactQuery) CreatedTo(t time.Time) *ContactQuery {
+	return q.setTime(&q.createdTo, "createdOn to", t)
+}
+
+// ChangedFrom matches contacts changed on or after the day of t
+func (q *ContactQuery) ChangedFrom(t time.Time) *ContactQuery {
+	return q.setTime(&q.changedFrom, "changedOn from", t)
+}
+
+// ChangedTo matches contacts changed on or before the day of t
+func (q *ContactQuery) ChangedTo(t time.Time) *ContactQuery {
+	return q.setTime(&q.changedTo, "changedOn to", t)
+}
+
+// SortBy sorts the listing by field.  A query takes a single sort key: the parameters are sent in alphabetical order,
+// so the priority of several keys would be lost.
+func (q *ContactQuery) SortBy(field ContactSortField, direction SortDirection) *ContactQuery {
+	switch field {
+	case SortByEmail, SortByName, SortByCampaignID, SortByCreatedOn, SortByChangedOn:
+	default:
+		q.problems = append(q.problems, fmt.Sprintf("contacts can't be sorted by %q", field))
+		return q
+	}
+	if direction != Asc && direction != Desc {
+		q.problems = append(q.problems, fmt.Sprintf("invalid sort direction %q for %s", direction, field))
+		return q
+	}
+	if q.sort != nil {
+		q.problems = append(q.problems, fmt.Sprintf("sorted by both %s and %s, only one sort key is supported", q.sort.field, field))
+		return q
+	}
+	q.sort = &contactSort{field: field, direction: direction}
+	return q
+}
+
+func (q *ContactQuery) set(key string, value string) *ContactQuery {
+	switch {
+	case strings.TrimSpace(value) == "":
+		q.problems = append(q.problems, fmt.Sprintf("%s must not be empty", key))
+	case q.query.Get(key) != "" && q.query.Get(key) != value:
+		q.problems = append(q.problems, fmt.Sprintf("%s set to both %q and %q", key, q.query.Get(key), value))
+	default:
+		q.query.Set(key, value)
+	}
+	return q
+}
+
+func (q *ContactQuery) setTime(dst **time.Time, name string, t time.Time) *ContactQuery {
+	if *dst != nil && !(*dst).Equal(t) {
+		q.problems = append(q.problems, fmt.Sprintf("%s set twice", name))
+		return q
+	}
+	*dst = &t
+	return q
+}
+
+// Values returns the query[...] and sort[...] parameters, or a *ValidationError listing every problem
+// with the query
+func (q *ContactQuery) Values() (url.Values, glitch.DataError) {
+	problems := append([]string{}, q.problems...)
+	if q.createdFrom != nil && q.createdTo != nil && q.createdFrom.After(*q.createdTo) {
+		problems = append(problems, "createdOn from is after createdOn to")
+	}
+	if q.changedFrom != nil && q.changedTo != nil && q.changedFrom.After(*q.changedTo) {
+		problems = append(problems, "changedOn from is after changedOn to")
+	}
+	if len(problems) > 0 {
+		return nil, newValidationError("Invalid contact query", problems...)
+	}
+
+	ret := url.Values{}
+	for k := range q.query {
+		ret.Set(fmt.Sprintf("query[%s]", k), q.query.Get(k))
+	}
+	for _, r := range []struct {
+		key string
+		t   *time.Time
+	}{
+		{"query[createdOn][from]", q.createdFrom},
+		{"query[createdOn][to]", q.createdTo},
+		{"query[changedOn][from]", q.changedFrom},
+		{"query[changedOn][to]", q.changedTo},
+	} {
+		if r.t != nil {
+			ret.Set(r.key, r.t.Format(CustomFieldDateFormat))
+		}
+	}
+	if q.sort != nil {
+		ret.Set(fmt.Sprintf("sort[%s]", q.sort.field), string(q.sort.direction))
+	}
+	return ret, nil
+}
+
+// contactQueryValues combines q with the listing parameters
+func contactQueryValues(q *ContactQuery, fields []string, page int32, perPage int32, additionalFlags *string) (url.Values, glitch.DataError) {
+	if q == nil {
+		q = NewContactQuery()
+	}
+	query, err := q.Values()
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range contactsQuery(nil, fields, nil, page, perPage, additionalFlags) {
+		query[k] = v
+	}
+	return query, nil
+}
+
+func (g *getResponseClient) QueryContacts(ctx context.Context, q *ContactQuery, fields []string, page int32, perPage int32, additionalFlags *string) ([]Contact, glitch.DataError) {
+	result := make([]Contact, 0)
+	query, err := contactQueryValues(q, fields, page, perPage, additionalFlags)
+	if err != nil {
+		return result, err
+	}
+	err = g.do(ctx, http.MethodGet, "/v3/contacts", query, nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateContactQuery(ctx context.Context, q *ContactQuery, fields []string, perPage int32, additionalFlags *string) *ContactIterator {
+	it := &ContactIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Contact, 0)
+		query, err := contactQueryValues(q, fields, page, perPage, additionalFlags)
+		if err != nil {
+			return 0, nil, err
+		}
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/contacts", query, nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
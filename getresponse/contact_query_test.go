@@ -0,0 +1,195 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_ContactQueryValues(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		query           *ContactQuery
+		expectedErrCode *string
+		expectedValues  url.Values
+	}
+
+	jan1 := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+	jan31 := time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	testcases := []testcase{
+		testcase{
+			name:           "empty",
+			query:          NewContactQuery(),
+			expectedValues: url.Values{},
+		},
+		testcase{
+			name: "every filter",
+			query: NewContactQuery().Email("@example.com").Name("Bob").CampaignID("V").Origin("api").
+				CreatedFrom(jan1).CreatedTo(jan31).ChangedFrom(jan1).ChangedTo(jan31).
+				SortBy(SortByCreatedOn, Desc),
+			expectedValues: url.Values{
+				"query[email]":           []string{"@example.com"},
+				"query[name]":            []string{"Bob"},
+				"query[campaignId]":      []string{"V"},
+				"query[origin]":          []string{"api"},
+				"query[createdOn][from]": []string{"2018-01-01"},
+				"query[createdOn][to]":   []string{"2018-01-31"},
+				"query[changedOn][from]": []string{"2018-01-01"},
+				"query[changedOn][to]":   []string{"2018-01-31"},
+				"sort[createdOn]":        []string{"desc"},
+			},
+		},
+		testcase{
+			name:           "same value twice",
+			query:          NewContactQuery().CampaignID("V").CampaignID("V"),
+			expectedValues: url.Values{"query[campaignId]": []string{"V"}},
+		},
+		testcase{
+			name:            "conflicting values",
+			query:           NewContactQuery().CampaignID("V").CampaignID("W"),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "empty value",
+			query:           NewContactQuery().Email(" "),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "conflicting dates",
+			query:           NewContactQuery().CreatedFrom(jan1).CreatedFrom(jan31),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "from after to",
+			query:           NewContactQuery().ChangedFrom(jan31).ChangedTo(jan1),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "invalid sort field",
+			query:           NewContactQuery().SortBy("origin", Asc),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "invalid sort direction",
+			query:           NewContactQuery().SortBy(SortByName, "up"),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "sorted twice",
+			query:           NewContactQuery().SortBy(SortByName, Asc).SortBy(SortByName, Desc),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "two sort keys",
+			query:           NewContactQuery().SortBy(SortByName, Asc).SortBy(SortByCreatedOn, Desc),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ret, err := tc.query.Values()
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedValues, ret) {
+				t.Fatalf("Actual values (%#v) did not match expected (%#v)", ret, tc.expectedValues)
+			}
+		})
+	}
+}
+
+func TestUnit_QueryContacts(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		query            *ContactQuery
+		expectedErrCode  *string
+		expectedResponse []Contact
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if r.URL.Path != "/v3/contacts" || q.Get("query[campaignId]") != "V" || q.Get("sort[email]") != "asc" || q.Get("page") != "1" || q.Get("additionalFlags") != "exactMatch" {
+					errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+					return
+				}
+				fmt.Fprint(w, `[{"contactId": "abc", "email": "a@example.com"}]`)
+			}),
+			query:            NewContactQuery().CampaignID("V").SortBy(SortByEmail, Asc),
+			expectedResponse: []Contact{Contact{ContactID: makeStringPtr("abc"), Email: makeStringPtr("a@example.com")}},
+		},
+		testcase{
+			name: "nil query",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `[]`)
+			}),
+			expectedResponse: []Contact{},
+		},
+		testcase{
+			name: "invalid query is not sent",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("Unexpected request %s", r.URL)
+			}),
+			query:           NewContactQuery().SortBy("origin", Asc),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat),
+			query:           NewContactQuery(),
+			expectedErrCode: makeStringPtr("1003"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.QueryContacts(context.Background(), tc.query, nil, 1, 10, makeStringPtr("exactMatch"))
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_IterateContactQuery(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query[origin]") != "import" {
+			errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+			return
+		}
+		w.Header().Set("TotalPages", "2")
+		w.Header().Set("CurrentPage", r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `[{"email": "page%s@example.com"}]`, r.URL.Query().Get("page"))
+	}), 5*time.Second)
+	defer ts.Close()
+
+	it := c.IterateContactQuery(context.Background(), NewContactQuery().Origin("import"), nil, 1, nil)
+	emails := []string{}
+	for it.Next() {
+		emails = append(emails, *it.Contact().Email)
+	}
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error: %v", it.Err())
+	}
+	if !reflect.DeepEqual([]string{"page1@example.com", "page2@example.com"}, emails) {
+		t.Fatalf("Unexpected emails %v", emails)
+	}
+
+	it = c.IterateContactQuery(context.Background(), NewContactQuery().Origin(""), nil, 1, nil)
+	if it.Next() {
+		t.Fatalf("Expected an invalid query to stop the iterator")
+	}
+	if it.Err() == nil || it.Err().Code() != ErrorLocalValidation {
+		t.Fatalf("Expected a local validation error, got %v", it.Err())
+	}
+}
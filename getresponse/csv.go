@@ -0,0 +1,405 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// CSV columns of contact fields.  Any other column is the name of a custom field.
+const (
+	CSVColumnContactID  = "contactId"
+	CSVColumnEmail      = "email"
+	CSVColumnName       = "name"
+	CSVColumnCampaignID = "campaignId"
+	CSVColumnOrigin     = "origin"
+	CSVColumnDayOfCycle = "dayOfCycle"
+	CSVColumnIPAddress  = "ipAddress"
+	CSVColumnCreatedOn  = "createdOn"
+	CSVColumnChangedOn  = "changedOn"
+	CSVColumnTags       = "tags"
+)
+
+// CSVValueSeparator separates the tags and the values of multi-value custom fields within a CSV cell
+const CSVValueSeparator = "|"
+
+// DefaultCSVColumns are exported when ExportContactsCSV is given no columns
+var DefaultCSVColumns = []string{CSVColumnEmail, CSVColumnName, CSVColumnCampaignID, CSVColumnCreatedOn, CSVColumnTags}
+
+// csvReadOnlyColumns are exported but ignored on import, so an exported file can be imported again
+var csvReadOnlyColumns = map[string]bool{
+	CSVColumnContactID: true,
+	CSVColumnOrigin:    true,
+	CSVColumnCreatedOn: true,
+	CSVColumnChangedOn: true,
+}
+
+func isContactColumn(column string) bool {
+	switch column {
+	case CSVColumnEmail, CSVColumnName, CSVColumnCampaignID, CSVColumnDayOfCycle, CSVColumnIPAddress, CSVColumnTags:
+		return true
+	}
+	return csvReadOnlyColumns[column]
+}
+
+// CSVImportOptions controls how ImportContactsCSV maps a CSV onto contacts
+type CSVImportOptions struct {
+	CampaignID string            // campaign of the rows without a campaignId column
+	Columns    map[string]string // renames CSV headers to columns, e.g. "E-mail" to "email"; map a header to "" to skip it
+	Upsert     bool              // update existing contacts with UpsertContact instead of creating every row
+}
+
+// CSVRowResult is the outcome of importing a CSV row
+type CSVRowResult struct {
+	Line    int      // line of the row in the CSV, the header being line 1
+	Email   string   // email of the row, when it has one
+	Contact *Contact // the stored contact, only set by upserts
+	Err     glitch.DataError
+}
+
+// CSVImportReport has the result of every row of an imported CSV, in order
+type CSVImportReport struct {
+	Rows []CSVRowResult
+}
+
+// Failures returns the rows that were not imported
+func (r CSVImportReport) Failures() []CSVRowResult {
+	ret := []CSVRowResult{}
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			ret = append(ret, row)
+		}
+	}
+	return ret
+}
+
+func csvError(err error, msg string) glitch.DataError {
+	return glitch.NewDataError(err, ErrorCSV, msg)
+}
+
+func invalidCSV(format string, args ...interface{}) glitch.DataError {
+	return newValidationError("Invalid CSV", fmt.Sprintf(format, args...))
+}
+
+func (g *getResponseClient) ExportContactsCSV(ctx context.Context, w io.Writer, columns []string, queryHash map[string]string) (int, glitch.DataError) {
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+
+	fields := []string{}
+	var byName map[string]CustomFieldDefinition
+	for _, column := range columns {
+		if column == CSVColumnCampaignID {
+			fields = append(fields, "campaign")
+			continue
+		}
+		if isContactColumn(column) {
+			fields = append(fields, column)
+			continue
+		}
+		if byName == nil {
+			var err glitch.DataError
+			if _, byName, err = g.customFieldDefinitions(ctx); err != nil {
+				return 0, err
+			}
+		}
+		if _, ok := byName[column]; !ok {
+			return 0, invalidCSV("unknown column %q", column)
+		}
+	}
+	if byName != nil {
+		fields = append(fields, "customFieldValues")
+	}
+
+	cw := csv.NewWriter(w)
+	rows := 0
+	// finish flushes the writer on every return so the rows counted before a failure reach w
+	finish := func(err glitch.DataError) (int, glitch.DataError) {
+		cw.Flush()
+		if flushErr := cw.Error(); flushErr != nil && err == nil {
+			err = csvError(flushErr, "Could not write the CSV")
+		}
+		return rows, err
+	}
+	if err := cw.Write(columns); err != nil {
+		return finish(csvError(err, "Could not write the CSV"))
+	}
+
+	var tagNames map[string]string
+	it := g.IterateContacts(ctx, queryHash, fields, nil, DefaultPerPage, nil)
+	for it.Next() {
+		c := it.Contact()
+		if tagNames == nil && hasUnnamedTags(c.Tags) {
+			var err glitch.DataError
+			if tagNames, err = g.tagNames(ctx); err != nil {
+				return finish(err)
+			}
+		}
+
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			record = append(record, contactCSVValue(c, column, byName, tagNames))
+		}
+		if err := cw.Write(record); err != nil {
+			return finish(csvError(err, "Could not write the CSV"))
+		}
+		rows++
+	}
+	return finish(it.Err())
+}
+
+func hasUnnamedTags(tags []Tag) bool {
+	for _, t := range tags {
+		if t.Name == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// tagNames maps the IDs of the account's tags to their names
+func (g *getResponseClient) tagNames(ctx context.Context) (map[string]string, glitch.DataError) {
+	ret := map[string]string{}
+	it := g.IterateTags(ctx, nil, nil, nil, 1000)
+	for it.Next() {
+		if it.Tag().Name != nil {
+			ret[it.Tag().TagID] = *it.Tag().Name
+		}
+	}
+	return ret, it.Err()
+}
+
+func contactCSVValue(c Contact, column string, byName map[string]CustomFieldDefinition, tagNames map[string]string) string {
+	str := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	ts := func(t *Timestamp) string {
+		if t == nil || t.IsZero() {
+			return ""
+		}
+		return t.Format(TimestampFormat)
+	}
+
+	switch column {
+	case CSVColumnContactID:
+		return str(c.ContactID)
+	case CSVColumnEmail:
+		return str(c.Email)
+	case CSVColumnName:
+		return str(c.Name)
+	case CSVColumnCampaignID:
+		if c.Campaign == nil {
+			return ""
+		}
+		return c.Campaign.CampaignID
+	case CSVColumnOrigin:
+		return str(c.Origin)
+	case CSVColumnDayOfCycle:
+		if c.DayOfCycle == nil {
+			return ""
+		}
+		return strconv.Itoa(int(*c.DayOfCycle))
+	case CSVColumnIPAddress:
+		return str(c.IPAddress)
+	case CSVColumnCreatedOn:
+		return ts(c.CreatedOn)
+	case CSVColumnChangedOn:
+		return ts(c.ChangedOn)
+	case CSVColumnTags:
+		names := make([]string, 0, len(c.Tags))
+		for _, t := range c.Tags {
+			if t.Name != nil {
+				names = append(names, *t.Name)
+			} else if name, ok := tagNames[t.TagID]; ok {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, CSVValueSeparator)
+	}
+
+	id := byName[column].CustomFieldID
+	for _, cf := range c.CustomFieldValues {
+		if cf.CustomFieldID == id {
+			return strings.Join(cf.Value, CSVValueSeparator)
+		}
+	}
+	return ""
+}
+
+func (g *getResponseClient) ImportContactsCSV(ctx context.Context, r io.Reader, opts CSVImportOptions) (CSVImportReport, glitch.DataError) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	report := CSVImportReport{}
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return report, invalidCSV("the CSV has no header")
+	}
+	if err != nil {
+		return report, csvError(err, "Could not read the CSV")
+	}
+
+	columns := make([]string, len(header))
+	var byName map[string]CustomFieldDefinition
+	hasEmail, hasCampaign := false, opts.CampaignID != ""
+	for i, h := range header {
+		column := strings.TrimSpace(h)
+		if mapped, ok := opts.Columns[column]; ok {
+			column = mapped
+		}
+		if column == "" || csvReadOnlyColumns[column] {
+			continue
+		}
+		hasEmail = hasEmail || column == CSVColumnEmail
+		hasCampaign = hasCampaign || column == CSVColumnCampaignID
+		if !isContactColumn(column) {
+			if byName == nil {
+				var err glitch.DataError
+				if _, byName, err = g.customFieldDefinitions(ctx); err != nil {
+					return report, err
+				}
+			}
+			if _, ok := byName[column]; !ok {
+				return report, invalidCSV("unknown column %q", h)
+			}
+		}
+		columns[i] = column
+	}
+	if !hasEmail {
+		return report, invalidCSV("the CSV has no email column")
+	}
+	if !hasCampaign {
+		return report, invalidCSV("the CSV has no campaignId column and no campaign was given")
+	}
+
+	tags := map[string]Tag{}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			report.Rows = append(report.Rows, CSVRowResult{Line: parseErr.StartLine, Err: csvError(err, "Invalid CSV row")})
+			continue
+		}
+		if err != nil {
+			return report, csvError(err, "Could not read the CSV")
+		}
+
+		line, _ := cr.FieldPos(0)
+		result := g.importCSVRow(ctx, columns, record, byName, tags, opts)
+		result.Line = line
+		report.Rows = append(report.Rows, result)
+		if ctx.Err() != nil {
+			return report, glitch.NewDataError(ctx.Err(), ErrorCanceled, "CSV import was canceled")
+		}
+	}
+}
+
+// importCSVRow creates or upserts the contact of a record.  tags caches the tags resolved so far.
+func (g *getResponseClient) importCSVRow(ctx context.Context, columns []string, record []string, byName map[string]CustomFieldDefinition, tags map[string]Tag, opts CSVImportOptions) CSVRowResult {
+	result := CSVRowResult{}
+	var name, ipAddress *string
+	var dayOfCycle *int32
+	campaignID := opts.CampaignID
+	customFields := []CustomField{}
+	tagNames := []string{}
+
+	for i, column := range columns {
+		value := strings.TrimSpace(record[i])
+		if column == "" || value == "" {
+			continue
+		}
+		switch column {
+		case CSVColumnEmail:
+			result.Email = value
+		case CSVColumnName:
+			name = &value
+		case CSVColumnCampaignID:
+			campaignID = value
+		case CSVColumnIPAddress:
+			ipAddress = &value
+		case CSVColumnDayOfCycle:
+			n, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				result.Err = invalidCSV("dayOfCycle %q is not a number", value)
+				return result
+			}
+			d := int32(n)
+			dayOfCycle = &d
+		case CSVColumnTags:
+			tagNames = append(tagNames, strings.Split(value, CSVValueSeparator)...)
+		default:
+			def := byName[column]
+			values := []interface{}{value}
+			if def.Type == CustomFieldTypeCheckbox || def.Type == CustomFieldTypeMultiSelect {
+				values = values[:0]
+				for _, v := range strings.Split(value, CSVValueSeparator) {
+					values = append(values, v)
+				}
+			}
+			customFields = append(customFields, NamedCustomFieldValue(column, values...))
+		}
+	}
+	if result.Email == "" {
+		result.Err = invalidCSV("the row has no email")
+		return result
+	}
+	if campaignID == "" {
+		result.Err = invalidCSV("the row has no campaignId")
+		return result
+	}
+
+	contactTags, err := g.csvTags(ctx, tagNames, tags)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if opts.Upsert {
+		c, err := g.UpsertContact(ctx, result.Email, name, dayOfCycle, campaignID, customFields, contactTags, ipAddress)
+		if err == nil {
+			result.Contact = &c
+		}
+		result.Err = err
+		return result
+	}
+	_, result.Err = g.createContactWithTags(ctx, result.Email, name, dayOfCycle, campaignID, customFields, contactTags, ipAddress)
+	return result
+}
+
+// csvTags resolves tag names, creating missing tags, and caches them in tags
+func (g *getResponseClient) csvTags(ctx context.Context, names []string, tags map[string]Tag) ([]Tag, glitch.DataError) {
+	missing := []string{}
+	for _, name := range names {
+		if _, ok := tags[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		resolved, err := g.ResolveTags(ctx, missing, true)
+		if err != nil {
+			return nil, err
+		}
+		for i, t := range resolved {
+			tags[missing[i]] = t
+		}
+	}
+
+	ret := make([]Tag, 0, len(names))
+	for _, name := range names {
+		ret = append(ret, tags[name])
+	}
+	return tagReferences(ret), nil
+}
@@ -0,0 +1,213 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// csvServer fakes the endpoints behind the CSV export and import, recording the contacts posted to it
+func csvServer(posted *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId": "pl", "name": "plan", "type": "single_select", "values": ["basic", "pro"]}, {"customFieldId": "in", "name": "interests", "type": "multi_select", "values": ["golf", "tennis"]}]`)
+		case r.URL.Path == "/v3/tags" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `[{"tagId": "t1", "name": "vip"}]`)
+		case r.URL.Path == "/v3/tags":
+			fmt.Fprint(w, `{"tagId": "t2", "name": "new"}`)
+		case r.URL.Path == "/v3/contacts" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `[
+				{"contactId": "a", "email": "a@example.com", "name": "A, Jr.", "campaign": {"campaignId": "V"}, "createdOn": "2018-01-15T10:00:00+0000",
+				 "tags": [{"tagId": "t1"}], "customFieldValues": [{"customFieldId": "in", "value": ["golf", "tennis"]}]},
+				{"contactId": "b", "email": "b@example.com", "campaign": {"campaignId": "V"}}
+			]`)
+		default:
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["email"] == "fail@example.com" {
+				errorHandler(http.StatusBadRequest, ErrorValidationError)(w, r)
+				return
+			}
+			*posted = append(*posted, body)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+}
+
+func TestUnit_ExportContactsCSV(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		columns         []string
+		expectedErrCode *string
+		expectedCSV     string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "default columns",
+			expectedCSV: "email,name,campaignId,createdOn,tags\n" +
+				"a@example.com,\"A, Jr.\",V,2018-01-15T10:00:00+0000,vip\n" +
+				"b@example.com,,V,,\n",
+		},
+		testcase{
+			name:    "custom fields",
+			columns: []string{"contactId", "interests", "plan"},
+			expectedCSV: "contactId,interests,plan\n" +
+				"a,golf|tennis,\n" +
+				"b,,\n",
+		},
+		testcase{
+			name:            "unknown column",
+			columns:         []string{"email", "shoeSize"},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := csvServer(&[]map[string]interface{}{})
+			defer ts.Close()
+			c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+			buf := &bytes.Buffer{}
+			n, err := c.ExportContactsCSV(context.Background(), buf, tc.columns, nil)
+			if !checkDataError(t, err, tc.expectedErrCode) {
+				return
+			}
+			if n != 2 || buf.String() != tc.expectedCSV {
+				t.Fatalf("Actual CSV (%q, %d rows) did not match expected (%q)", buf.String(), n, tc.expectedCSV)
+			}
+		})
+	}
+}
+
+func TestUnit_ExportContactsCSVFailingPage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			errorHandler(http.StatusServiceUnavailable, ErrorInternalError)(w, r)
+			return
+		}
+		w.Header().Set("TotalPages", "2")
+		w.Header().Set("CurrentPage", "1")
+		fmt.Fprint(w, `[{"email": "a@example.com"}]`)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	buf := &bytes.Buffer{}
+	n, err := c.ExportContactsCSV(context.Background(), buf, []string{CSVColumnEmail}, nil)
+	checkDataError(t, err, makeStringPtr("1"))
+	if expected := "email\na@example.com\n"; n != 1 || buf.String() != expected {
+		t.Fatalf("Actual CSV (%q, %d rows) did not match expected (%q)", buf.String(), n, expected)
+	}
+}
+
+func TestUnit_ImportContactsCSV(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		csv             string
+		opts            CSVImportOptions
+		expectedErrCode *string
+		expectedPosted  []map[string]interface{}
+		expectedFailed  []int
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			csv: "E-mail,name,tags,interests,createdOn,notes\n" +
+				"a@example.com,A,vip|new,golf|tennis,2018-01-15T10:00:00+0000,x\n" +
+				"b@example.com,,,,,\n",
+			opts: CSVImportOptions{CampaignID: "V", Columns: map[string]string{"E-mail": "email", "notes": ""}},
+			expectedPosted: []map[string]interface{}{
+				map[string]interface{}{
+					"email":             "a@example.com",
+					"name":              "A",
+					"campaign":          map[string]interface{}{"campaignId": "V"},
+					"tags":              []interface{}{map[string]interface{}{"tagId": "t1"}, map[string]interface{}{"tagId": "t2"}},
+					"customFieldValues": []interface{}{map[string]interface{}{"customFieldId": "in", "value": []interface{}{"golf", "tennis"}}},
+				},
+				map[string]interface{}{
+					"email":    "b@example.com",
+					"campaign": map[string]interface{}{"campaignId": "V"},
+				},
+			},
+		},
+		testcase{
+			name: "failed rows",
+			csv: "email,campaignId,plan,dayOfCycle\n" +
+				",V,,\n" +
+				"b@example.com,V,gold,\n" +
+				"c@example.com,V,,soon\n" +
+				"fail@example.com,V,,\n" +
+				"\"e@example.com,V\n" +
+				"",
+			expectedFailed: []int{2, 3, 4, 5, 6},
+		},
+		testcase{
+			name:            "unknown column",
+			csv:             "email,shoeSize\na@example.com,9\n",
+			opts:            CSVImportOptions{CampaignID: "V"},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "no campaign",
+			csv:             "email\na@example.com\n",
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "no email",
+			csv:             "name\nA\n",
+			opts:            CSVImportOptions{CampaignID: "V"},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			posted := []map[string]interface{}{}
+			ts := csvServer(&posted)
+			defer ts.Close()
+			c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+			report, err := c.ImportContactsCSV(context.Background(), strings.NewReader(tc.csv), tc.opts)
+			if !checkDataError(t, err, tc.expectedErrCode) {
+				return
+			}
+			if tc.expectedPosted != nil && !reflect.DeepEqual(tc.expectedPosted, posted) {
+				t.Fatalf("Actual contacts (%#v) did not match expected (%#v)", posted, tc.expectedPosted)
+			}
+			failed := []int{}
+			for _, f := range report.Failures() {
+				failed = append(failed, f.Line)
+			}
+			if tc.expectedFailed == nil {
+				tc.expectedFailed = []int{}
+			}
+			if !reflect.DeepEqual(tc.expectedFailed, failed) {
+				t.Fatalf("Actual failed lines (%v) did not match expected (%v)", failed, tc.expectedFailed)
+			}
+		})
+	}
+}
+
+func TestUnit_ImportContactsCSVNilContext(t *testing.T) {
+	posted := []map[string]interface{}{}
+	ts := csvServer(&posted)
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	report, err := c.ImportContactsCSV(nil, strings.NewReader("email\na@example.com\n"), CSVImportOptions{CampaignID: "V"})
+	if err != nil || len(report.Failures()) != 0 || len(posted) != 1 {
+		t.Fatalf("Unexpected report %#v (%#v)", report, err)
+	}
+}
@@ -0,0 +1,277 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// Formats GR expects date and datetime custom field values in
+const (
+	CustomFieldDateFormat     = "2006-01-02"
+	CustomFieldDatetimeFormat = "2006-01-02 15:04:05"
+)
+
+var phonePattern = regexp.MustCompile(`^\+[0-9]{7,15}$`)
+
+// CustomFieldValue returns a custom field whose values the client encodes and validates against the definition with
+// this ID.  Values may be strings, fmt.Stringers, time.Time for date and datetime fields or any Go number.
+func CustomFieldValue(customFieldID string, values ...interface{}) CustomField {
+	return CustomField{CustomFieldID: customFieldID, typed: values}
+}
+
+// NamedCustomFieldValue is CustomFieldValue for the custom field with this name, e.g. "birthdate"
+func NamedCustomFieldValue(name string, values ...interface{}) CustomField {
+	return CustomField{name: name, typed: values}
+}
+
+// customFieldCache holds the account's custom field definitions, loaded on first use
+type customFieldCache struct {
+	mu         sync.Mutex
+	loaded     bool
+	generation uint64 // bumped by reset so a load that overlapped it isn't stored
+	byID       map[string]CustomFieldDefinition
+	byName     map[string]CustomFieldDefinition
+}
+
+func (c *customFieldCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loaded = false
+	c.generation++
+}
+
+// customFieldDefinitions returns the cached definitions keyed by ID and by name, loading them if needed.  The load
+// runs without holding the cache lock.
+func (g *getResponseClient) customFieldDefinitions(ctx context.Context) (map[string]CustomFieldDefinition, map[string]CustomFieldDefinition, glitch.DataError) {
+	g.cfCache.mu.Lock()
+	if g.cfCache.loaded {
+		defer g.cfCache.mu.Unlock()
+		return g.cfCache.byID, g.cfCache.byName, nil
+	}
+	generation := g.cfCache.generation
+	g.cfCache.mu.Unlock()
+
+	byID := map[string]CustomFieldDefinition{}
+	byName := map[string]CustomFieldDefinition{}
+	it := g.IterateCustomFields(ctx, nil, nil, nil, 1000)
+	for it.Next() {
+		def := it.CustomField()
+		byID[def.CustomFieldID] = def
+		byName[def.Name] = def
+	}
+	if it.Err() != nil {
+		return nil, nil, it.Err()
+	}
+
+	g.cfCache.mu.Lock()
+	defer g.cfCache.mu.Unlock()
+	if g.cfCache.generation == generation {
+		g.cfCache.byID, g.cfCache.byName, g.cfCache.loaded = byID, byName, true
+	}
+	return byID, byName, nil
+}
+
+// encodeCustomFields resolves names and encodes typed values into the string values GR expects, validating them
+// against the definitions.  Plain string values are only validated when the client was built with
+// WithCustomFieldValidation.
+func (g *getResponseClient) encodeCustomFields(ctx context.Context, fields []CustomField) ([]CustomField, glitch.DataError) {
+	if !g.needsDefinitions(fields) {
+		return fields, nil
+	}
+	byID, byName, err := g.customFieldDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCustomFieldsWith(fields, byID, byName)
+}
+
+// needsDefinitions reports whether encoding fields requires the custom field definitions
+func (g *getResponseClient) needsDefinitions(fields []CustomField) bool {
+	needed := g.validate && len(fields) > 0
+	for _, f := range fields {
+		needed = needed || f.typed != nil || f.name != ""
+	}
+	return needed
+}
+
+// encodeCustomFieldsWith is encodeCustomFields with definitions the caller already loaded
+func encodeCustomFieldsWith(fields []CustomField, byID map[string]CustomFieldDefinition, byName map[string]CustomFieldDefinition) ([]CustomField, glitch.DataError) {
+	ret := make([]CustomField, 0, len(fields))
+	invalid := []FieldError{}
+	for _, f := range fields {
+		def, ok := byID[f.CustomFieldID]
+		if f.name != "" {
+			def, ok = byName[f.name]
+		}
+		if !ok {
+			invalid = append(invalid, FieldError{CustomFieldID: f.CustomFieldID, Name: f.name, Reason: "unknown custom field"})
+			continue
+		}
+
+		values := f.typed
+		if values == nil {
+			values = make([]interface{}, 0, len(f.Value))
+			for _, v := range f.Value {
+				values = append(values, v)
+			}
+		}
+
+		encoded, fieldErrs := encodeCustomFieldValues(def, values)
+		if len(fieldErrs) > 0 {
+			invalid = append(invalid, fieldErrs...)
+			continue
+		}
+		ret = append(ret, CustomField{CustomFieldID: def.CustomFieldID, Value: encoded, Href: f.Href})
+	}
+
+	if len(invalid) > 0 {
+		return nil, &ValidationError{Message: "Invalid custom field values", Fields: invalid}
+	}
+	return ret, nil
+}
+
+// encodeCustomFieldValues encodes values for the field described by def
+func encodeCustomFieldValues(def CustomFieldDefinition, values []interface{}) ([]string, []FieldError) {
+	fieldErr := func(v interface{}, reason string) FieldError {
+		return FieldError{CustomFieldID: def.CustomFieldID, Name: def.Name, Value: v, Reason: reason}
+	}
+
+	switch def.Type {
+	case CustomFieldTypeRadio, CustomFieldTypeSingleSelect, CustomFieldTypeGender, CustomFieldTypeCountry:
+		if len(values) != 1 {
+			return nil, []FieldError{fieldErr(values, fmt.Sprintf("expected exactly one value, got %d", len(values)))}
+		}
+	}
+
+	ret := make([]string, 0, len(values))
+	errs := []FieldError{}
+	for _, v := range values {
+		s, err := encodeCustomFieldValue(def, v)
+		if err != nil {
+			errs = append(errs, fieldErr(v, err.Error()))
+			continue
+		}
+		ret = append(ret, s)
+	}
+	return ret, errs
+}
+
+func encodeCustomFieldValue(def CustomFieldDefinition, v interface{}) (string, error) {
+	switch def.Type {
+	case CustomFieldTypeDate, CustomFieldTypeDatetime:
+		layout := CustomFieldDateFormat
+		if def.Type == CustomFieldTypeDatetime {
+			layout = CustomFieldDatetimeFormat
+		}
+		switch t := v.(type) {
+		case time.Time:
+			return t.Format(layout), nil
+		case *time.Time:
+			if t == nil {
+				return "", errors.New("expected a time, got a nil *time.Time")
+			}
+			return t.Format(layout), nil
+		}
+		s, err := stringValue(v)
+		if err != nil {
+			return "", err
+		}
+		if _, err := time.Parse(layout, s); err != nil {
+			return "", fmt.Errorf("expected a time.Time or a %s value", layout)
+		}
+		return s, nil
+
+	case CustomFieldTypeNumber, CustomFieldTypeCurrency:
+		if s, ok := numberValue(v); ok {
+			return s, nil
+		}
+		s, err := stringValue(v)
+		if err != nil {
+			return "", err
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return "", errors.New("expected a number")
+		}
+		return s, nil
+	}
+
+	s, err := stringValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch def.Type {
+	case CustomFieldTypePhone:
+		if !phonePattern.MatchString(s) {
+			return "", errors.New("expected a phone number with country code, e.g. +48123456789")
+		}
+	case CustomFieldTypeIP:
+		if net.ParseIP(s) == nil {
+			return "", errors.New("expected an IP address")
+		}
+	case CustomFieldTypeURL:
+		if u, err := url.ParseRequestURI(s); err != nil || u.Scheme == "" || u.Host == "" {
+			return "", errors.New("expected an absolute URL")
+		}
+	}
+
+	if len(def.Values) > 0 {
+		for _, allowed := range def.Values {
+			if s == allowed {
+				return s, nil
+			}
+		}
+		return "", fmt.Errorf("expected one of %s", strings.Join(def.Values, ", "))
+	}
+	return s, nil
+}
+
+func stringValue(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case fmt.Stringer:
+		return s.String(), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", v)
+}
+
+func numberValue(v interface{}) (string, bool) {
+	switch n := v.(type) {
+	case int:
+		return strconv.FormatInt(int64(n), 10), true
+	case int8:
+		return strconv.FormatInt(int64(n), 10), true
+	case int16:
+		return strconv.FormatInt(int64(n), 10), true
+	case int32:
+		return strconv.FormatInt(int64(n), 10), true
+	case int64:
+		return strconv.FormatInt(n, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint64:
+		return strconv.FormatUint(n, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(n), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64), true
+	}
+	return "", false
+}
@@ -0,0 +1,182 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnit_EncodeCustomFieldValues(t *testing.T) {
+
+	type testcase struct {
+		name          string
+		def           CustomFieldDefinition
+		values        []interface{}
+		expected      []string
+		expectedError bool
+	}
+
+	birthdate := time.Date(1990, 1, 2, 15, 4, 5, 0, time.UTC)
+	testcases := []testcase{
+		testcase{name: "date from time", def: CustomFieldDefinition{Type: CustomFieldTypeDate}, values: []interface{}{birthdate}, expected: []string{"1990-01-02"}},
+		testcase{name: "datetime from time", def: CustomFieldDefinition{Type: CustomFieldTypeDatetime}, values: []interface{}{birthdate}, expected: []string{"1990-01-02 15:04:05"}},
+		testcase{name: "nil time", def: CustomFieldDefinition{Type: CustomFieldTypeDate}, values: []interface{}{(*time.Time)(nil)}, expectedError: true},
+		testcase{name: "date from string", def: CustomFieldDefinition{Type: CustomFieldTypeDate}, values: []interface{}{"1990-01-02"}, expected: []string{"1990-01-02"}},
+		testcase{name: "bad date", def: CustomFieldDefinition{Type: CustomFieldTypeDate}, values: []interface{}{"02/01/1990"}, expectedError: true},
+		testcase{name: "number", def: CustomFieldDefinition{Type: CustomFieldTypeNumber}, values: []interface{}{42, 1.5, "7"}, expected: []string{"42", "1.5", "7"}},
+		testcase{name: "bad number", def: CustomFieldDefinition{Type: CustomFieldTypeNumber}, values: []interface{}{"seven"}, expectedError: true},
+		testcase{name: "phone", def: CustomFieldDefinition{Type: CustomFieldTypePhone}, values: []interface{}{"+48123456789"}, expected: []string{"+48123456789"}},
+		testcase{name: "bad phone", def: CustomFieldDefinition{Type: CustomFieldTypePhone}, values: []interface{}{"123-456"}, expectedError: true},
+		testcase{name: "ip", def: CustomFieldDefinition{Type: CustomFieldTypeIP}, values: []interface{}{"127.0.0.1"}, expected: []string{"127.0.0.1"}},
+		testcase{name: "bad url", def: CustomFieldDefinition{Type: CustomFieldTypeURL}, values: []interface{}{"example.com"}, expectedError: true},
+		testcase{name: "single select", def: CustomFieldDefinition{Type: CustomFieldTypeSingleSelect, Values: []string{"basic", "pro"}}, values: []interface{}{"pro"}, expected: []string{"pro"}},
+		testcase{name: "single select unknown option", def: CustomFieldDefinition{Type: CustomFieldTypeSingleSelect, Values: []string{"basic", "pro"}}, values: []interface{}{"team"}, expectedError: true},
+		testcase{name: "single select many values", def: CustomFieldDefinition{Type: CustomFieldTypeSingleSelect, Values: []string{"basic", "pro"}}, values: []interface{}{"basic", "pro"}, expectedError: true},
+		testcase{name: "multi select", def: CustomFieldDefinition{Type: CustomFieldTypeMultiSelect, Values: []string{"a", "b", "c"}}, values: []interface{}{"a", "c"}, expected: []string{"a", "c"}},
+		testcase{name: "country", def: CustomFieldDefinition{Type: CustomFieldTypeCountry, Values: []string{"Poland", "United States"}}, values: []interface{}{"Poland"}, expected: []string{"Poland"}},
+		testcase{name: "unsupported type", def: CustomFieldDefinition{Type: CustomFieldTypeText}, values: []interface{}{struct{}{}}, expectedError: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ret, errs := encodeCustomFieldValues(tc.def, tc.values)
+			if tc.expectedError != (len(errs) > 0) {
+				t.Fatalf("Actual errors (%#v) did not match expectation (%t)", errs, tc.expectedError)
+			}
+			if !tc.expectedError && !reflect.DeepEqual(tc.expected, ret) {
+				t.Fatalf("Actual values (%#v) did not match expected (%#v)", ret, tc.expected)
+			}
+		})
+	}
+}
+
+// customFieldsServer serves the custom field definitions and records contact bodies posted to it
+func customFieldsServer(definitionLoads *int, posted *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/custom-fields":
+			*definitionLoads++
+			fmt.Fprint(w, `[{"customFieldId": "bd", "name": "birthdate", "type": "date"}, {"customFieldId": "pl", "name": "plan", "type": "single_select", "values": ["basic", "pro"]}]`)
+		default:
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			*posted = append(*posted, body)
+			fmt.Fprint(w, `{"contactId": "abc"}`)
+		}
+	}))
+}
+
+func TestUnit_TypedCustomFields(t *testing.T) {
+	loads := 0
+	posted := []map[string]interface{}{}
+	ts := customFieldsServer(&loads, &posted)
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	err := c.CreateContact(context.Background(), "foo@bar.baz", nil, nil, "V", []CustomField{
+		NamedCustomFieldValue("birthdate", time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC)),
+		CustomFieldValue("pl", "pro"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	_, err = c.UpdateContactCustomFields(context.Background(), "abc", []CustomField{NamedCustomFieldValue("plan", "basic")})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"customFieldId": "bd", "value": []interface{}{"1990-01-02"}},
+		map[string]interface{}{"customFieldId": "pl", "value": []interface{}{"pro"}},
+	}
+	if len(posted) != 2 || !reflect.DeepEqual(expected, posted[0]["customFieldValues"]) {
+		t.Fatalf("Actual posted custom fields (%#v) did not match expected (%#v)", posted, expected)
+	}
+	if loads != 1 {
+		t.Fatalf("Custom field definitions were loaded %d times, expected once", loads)
+	}
+}
+
+func TestUnit_TypedCustomFieldsInvalid(t *testing.T) {
+	loads := 0
+	posted := []map[string]interface{}{}
+	ts := customFieldsServer(&loads, &posted)
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	_, err := c.UpdateContact(context.Background(), "abc", Contact{CustomFieldValues: []CustomField{
+		NamedCustomFieldValue("birthdate", "yesterday"),
+		NamedCustomFieldValue("plan", "team"),
+		NamedCustomFieldValue("shoe_size", 42),
+	}})
+	if err == nil || err.Code() != ErrorLocalValidation {
+		t.Fatalf("Actual error (%#v) did not match expected (%#v)", err, ErrorLocalValidation)
+	}
+	validationErr, ok := err.(*ValidationError)
+	if !ok || len(validationErr.Fields) != 3 || !strings.Contains(err.Error(), "shoe_size: unknown custom field") {
+		t.Fatalf("Expected every invalid field to be reported, got (%#v)", err)
+	}
+	if len(posted) != 0 {
+		t.Fatalf("Invalid values were sent to GR (%#v)", posted)
+	}
+}
+
+func TestUnit_WithCustomFieldValidation(t *testing.T) {
+	loads := 0
+	posted := []map[string]interface{}{}
+	ts := customFieldsServer(&loads, &posted)
+	defer ts.Close()
+
+	fields := []CustomField{CustomField{CustomFieldID: "pl", Value: []string{"team"}}}
+
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+	if _, err := c.UpdateContactCustomFields(context.Background(), "abc", fields); err != nil {
+		t.Fatalf("Plain values should only be validated on request, got (%#v)", err)
+	}
+
+	c = NewClientWithOptions(WithBaseURL(ts.URL), WithCustomFieldValidation())
+	_, err := c.UpdateContactCustomFields(context.Background(), "abc", fields)
+	if err == nil || err.Code() != ErrorLocalValidation {
+		t.Fatalf("Actual error (%#v) did not match expected (%#v)", err, ErrorLocalValidation)
+	}
+}
+
+func TestUnit_CustomFieldCacheReset(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	loads := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loads++
+		if loads == 1 {
+			started <- struct{}{}
+			<-release
+		}
+		fmt.Fprintf(w, `[{"customFieldId": "pl", "name": "plan_%d", "type": "text"}]`, loads)
+	}))
+	defer ts.Close()
+	g := NewClientWithOptions(WithBaseURL(ts.URL)).(*getResponseClient)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.customFieldDefinitions(context.Background())
+	}()
+	<-started
+	g.cfCache.reset()
+	close(release)
+	<-done
+
+	_, byName, err := g.customFieldDefinitions(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if _, ok := byName["plan_2"]; !ok || loads != 2 {
+		t.Fatalf("A load that overlapped a reset was cached (%#v after %d loads)", byName, loads)
+	}
+}
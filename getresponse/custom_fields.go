@@ -0,0 +1,103 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+func (g *getResponseClient) GetCustomFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]CustomFieldDefinition, glitch.DataError) {
+	result := make([]CustomFieldDefinition, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/custom-fields", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateCustomFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *CustomFieldIterator {
+	it := &CustomFieldIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]CustomFieldDefinition, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/custom-fields", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetCustomField(ctx context.Context, ID string, fields []string) (CustomFieldDefinition, glitch.DataError) {
+	result := CustomFieldDefinition{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/custom-fields/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) GetCustomFieldByName(ctx context.Context, name string) (CustomFieldDefinition, glitch.DataError) {
+	it := g.IterateCustomFields(ctx, map[string]string{"name": name}, nil, nil, DefaultPerPage)
+	found, err := findExact(it, func() bool { return it.CustomField().Name == name })
+	if err != nil {
+		return CustomFieldDefinition{}, err
+	}
+	if found {
+		return it.CustomField(), nil
+	}
+	return CustomFieldDefinition{}, &APIError{ErrorResponse: ErrorResponse{
+		HTTPStatus: http.StatusNotFound,
+		ErrorCode:  ErrorResourceNotFound,
+		Message:    fmt.Sprintf("Custom field %q not found", name),
+	}}
+}
+
+func (g *getResponseClient) CreateCustomField(ctx context.Context, definition CustomFieldDefinition) (CustomFieldDefinition, glitch.DataError) {
+	result := CustomFieldDefinition{}
+	err := g.do(ctx, http.MethodPost, "/v3/custom-fields", nil, definition, &result)
+	g.cfCache.reset()
+	return result, err
+}
+
+func (g *getResponseClient) UpdateCustomField(ctx context.Context, ID string, newData CustomFieldDefinition) (CustomFieldDefinition, glitch.DataError) {
+	result := CustomFieldDefinition{}
+	err := g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/custom-fields/%s", ID), nil, newData, &result)
+	g.cfCache.reset()
+	return result, err
+}
+
+func (g *getResponseClient) DeleteCustomField(ctx context.Context, ID string) glitch.DataError {
+	err := g.do(ctx, http.MethodDelete, fmt.Sprintf("/v3/custom-fields/%s", ID), nil, nil, nil)
+	g.cfCache.reset()
+	return err
+}
+
+// CustomFieldIterator walks the pages of a custom field listing.  Call Next until it returns false and then check Err.
+type CustomFieldIterator struct {
+	p   *pager
+	buf []CustomFieldDefinition
+	cur CustomFieldDefinition
+}
+
+// Next advances to the next custom field, fetching the next page when needed
+func (it *CustomFieldIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// CustomField returns the custom field the iterator is positioned at
+func (it *CustomFieldIterator) CustomField() CustomFieldDefinition {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *CustomFieldIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *CustomFieldIterator) Pagination() Pagination {
+	return it.p.pagination
+}
@@ -0,0 +1,262 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_GetCustomFields(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse []CustomFieldDefinition
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/custom-fields" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `[{"customFieldId": "y8jnp", "name": "birthdate", "type": "date", "hidden": "false", "values": []}]`)
+			}),
+			expectedResponse: []CustomFieldDefinition{CustomFieldDefinition{CustomFieldID: "y8jnp", Name: "birthdate", Type: CustomFieldTypeDate, Hidden: makeStringPtr("false"), Values: []string{}}},
+		},
+		testcase{
+			name:            "unmarshal error",
+			handler:         http.HandlerFunc(undecodableHandler),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusForbidden, ErrorForbidden),
+			expectedErrCode: makeStringPtr("1002"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetCustomFields(context.Background(), nil, nil, nil, 1, 10)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_GetCustomField(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse CustomFieldDefinition
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/custom-fields/y8jnp" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `{"customFieldId": "y8jnp", "name": "plan", "type": "single_select", "values": ["basic", "pro"]}`)
+			}),
+			expectedResponse: CustomFieldDefinition{CustomFieldID: "y8jnp", Name: "plan", Type: CustomFieldTypeSingleSelect, Values: []string{"basic", "pro"}},
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusNotFound, ErrorResourceNotFound),
+			expectedErrCode: makeStringPtr("1013"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetCustomField(context.Background(), "y8jnp", nil)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_GetCustomFieldByName(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		lookup           string
+		expectedErrCode  *string
+		expectedResponse CustomFieldDefinition
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query[name]") == "broken" {
+			errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+			return
+		}
+		fmt.Fprint(w, `[{"customFieldId": "aaa", "name": "birthdate_old"}, {"customFieldId": "bbb", "name": "birthdate"}]`)
+	})
+
+	testcases := []testcase{
+		testcase{
+			name:             "exact match",
+			lookup:           "birthdate",
+			expectedResponse: CustomFieldDefinition{CustomFieldID: "bbb", Name: "birthdate"},
+		},
+		testcase{
+			name:            "no exact match",
+			lookup:          "birth",
+			expectedErrCode: makeStringPtr("1013"),
+		},
+		testcase{
+			name:            "error response",
+			lookup:          "broken",
+			expectedErrCode: makeStringPtr("1003"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetCustomFieldByName(context.Background(), tc.lookup)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+			if err != nil && tc.lookup == "birth" && !errors.Is(err, ErrResourceNotFound) {
+				t.Fatalf("Error (%#v) does not match ErrResourceNotFound", err)
+			}
+		})
+	}
+}
+
+func TestUnit_CreateCustomField(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse CustomFieldDefinition
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v3/custom-fields" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"customFieldId": "y8jnp", "name": "birthdate", "type": "date", "hidden": "false"}`)
+			}),
+			expectedResponse: CustomFieldDefinition{CustomFieldID: "y8jnp", Name: "birthdate", Type: CustomFieldTypeDate, Hidden: makeStringPtr("false")},
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusConflict, ErrorResourceAlreadyExists),
+			expectedErrCode: makeStringPtr("1008"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.CreateCustomField(context.Background(), CustomFieldDefinition{Name: "birthdate", Type: CustomFieldTypeDate, Hidden: makeStringPtr("false")})
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_UpdateCustomField(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse CustomFieldDefinition
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v3/custom-fields/y8jnp" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `{"customFieldId": "y8jnp", "name": "plan", "values": ["basic", "pro", "team"]}`)
+			}),
+			expectedResponse: CustomFieldDefinition{CustomFieldID: "y8jnp", Name: "plan", Values: []string{"basic", "pro", "team"}},
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusBadRequest, ErrorValidationError),
+			expectedErrCode: makeStringPtr("1000"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.UpdateCustomField(context.Background(), "y8jnp", CustomFieldDefinition{Values: []string{"basic", "pro", "team"}})
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_DeleteCustomField(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		handler         http.HandlerFunc
+		expectedErrCode *string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/v3/custom-fields/y8jnp" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusBadRequest, ErrorResourceInUse),
+			expectedErrCode: makeStringPtr("1009"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			checkDataError(t, c.DeleteCustomField(context.Background(), "y8jnp"), tc.expectedErrCode)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package getresponse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// APIError is an error response from the GR api.  It is a glitch.DataError whose Code is the GR error code and it
+// matches the Err* values with errors.Is:
+//
+//	if errors.Is(err, getresponse.ErrResourceAlreadyExists) { ... }
+type APIError struct {
+	ErrorResponse
+	cause glitch.DataError
+}
+
+func newAPIError(code int) *APIError {
+	return &APIError{ErrorResponse: ErrorResponse{ErrorCode: code}}
+}
+
+// Errors matching the GR error codes with errors.Is
+var (
+	ErrInternalError           = newAPIError(ErrorInternalError)
+	ErrValidationError         = newAPIError(ErrorValidationError)
+	ErrRelatedResourceNotFound = newAPIError(ErrorRelatedResourceNotFound)
+	ErrForbidden               = newAPIError(ErrorForbidden)
+	ErrInvalidParameterFormat  = newAPIError(ErrorInvalidParameterFormat)
+	ErrInvalidHash             = newAPIError(ErrorInvalidHash)
+	ErrMissingParameter        = newAPIError(ErrorMissingParameter)
+	ErrInvalidParameterType    = newAPIError(ErrorInvalidParameterType)
+	ErrInvalidParameterLength  = newAPIError(ErrorInvalidParameterLength)
+	ErrResourceAlreadyExists   = newAPIError(ErrorResourceAlreadyExists)
+	ErrResourceInUse           = newAPIError(ErrorResourceInUse)
+	ErrExternalError           = newAPIError(ErrorExternalError)
+	ErrMessageAlreadySending   = newAPIError(ErrorMessageAlreadySending)
+	ErrMessageParsing          = newAPIError(ErrorMessageParsing)
+	ErrResourceNotFound        = newAPIError(ErrorResourceNotFound)
+	ErrAuthenticationFailure   = newAPIError(ErrorAuthenticationFailure)
+	ErrRequestQuotaReached     = newAPIError(ErrorRequestQuotaReached)
+	ErrTemporarilyBlocked      = newAPIError(ErrorTemporarilyBlocked)
+	ErrPermanentlyBlocked      = newAPIError(ErrorPermanentlyBlocked)
+	ErrIPBlocked               = newAPIError(ErrorIPBlocked)
+	ErrInvalidRequestHeaders   = newAPIError(ErrorInvalidRequestHeaders)
+)
+
+// Error satisfies the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Code: [%d] Message: [%s] Context: [%s] UUID: [%s]", e.ErrorCode, e.Message, strings.Join(e.Context, ", "), e.UUID)
+}
+
+// Inner returns nil, an APIError does not originate from another error
+func (e *APIError) Inner() error {
+	return nil
+}
+
+// Code returns the GR error code, e.g. "1008"
+func (e *APIError) Code() string {
+	return strconv.Itoa(e.ErrorCode)
+}
+
+// Wrap returns a copy of the error with err as its cause, so the Err* values are never modified
+func (e *APIError) Wrap(err glitch.DataError) glitch.DataError {
+	ret := *e
+	ret.cause = err
+	return &ret
+}
+
+// GetCause returns the cause of the error
+func (e *APIError) GetCause() glitch.DataError {
+	return e.cause
+}
+
+// Is reports whether target is an APIError with the same GR error code
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.ErrorCode == e.ErrorCode
+}
+
+// FieldError is a custom field value which failed local validation
+type FieldError struct {
+	CustomFieldID string
+	Name          string
+	Value         interface{}
+	Reason        string
+}
+
+// ValidationError is returned when the client rejects a request before sending it to GR.  It lists every problem
+// found, Fields holds the custom field values among them.  Its Code is ErrorLocalValidation.
+type ValidationError struct {
+	Message  string // what was rejected, e.g. "Invalid contact query"
+	Problems []string
+	Fields   []FieldError
+	cause    glitch.DataError
+}
+
+func newValidationError(message string, problems ...string) *ValidationError {
+	return &ValidationError{Message: message, Problems: problems}
+}
+
+// Error satisfies the error interface
+func (e *ValidationError) Error() string {
+	msgs := append([]string{}, e.Problems...)
+	for _, f := range e.Fields {
+		field := f.Name
+		if field == "" {
+			field = f.CustomFieldID
+		}
+		msgs = append(msgs, fmt.Sprintf("%s: %s (%v)", field, f.Reason, f.Value))
+	}
+	return fmt.Sprintf("Code: [%s] Message: [%s] Problems: [%s]", ErrorLocalValidation, e.Message, strings.Join(msgs, "; "))
+}
+
+// Inner returns nil, a ValidationError does not originate from another error
+func (e *ValidationError) Inner() error {
+	return nil
+}
+
+// Code returns ErrorLocalValidation
+func (e *ValidationError) Code() string {
+	return ErrorLocalValidation
+}
+
+// Wrap sets err as the cause of the error and returns itself
+func (e *ValidationError) Wrap(err glitch.DataError) glitch.DataError {
+	e.cause = err
+	return e
+}
+
+// GetCause returns the cause of the error
+func (e *ValidationError) GetCause() glitch.DataError {
+	return e.cause
+}
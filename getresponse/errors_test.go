@@ -0,0 +1,92 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_APIError(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		body             string
+		target           error
+		expectedIs       bool
+		expectedResponse ErrorResponse
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:       "duplicate",
+			body:       `{"httpStatus": 409, "code": 1008, "message": "Contact already added", "moreInfo": "https://apidocs.getresponse.com/en/v3/errors/1008", "context": ["email"], "uuid": "abc"}`,
+			target:     ErrResourceAlreadyExists,
+			expectedIs: true,
+			expectedResponse: ErrorResponse{
+				HTTPStatus: 409,
+				ErrorCode:  ErrorResourceAlreadyExists,
+				Message:    "Contact already added",
+				MoreInfo:   "https://apidocs.getresponse.com/en/v3/errors/1008",
+				Context:    []string{"email"},
+				UUID:       "abc",
+			},
+		},
+		testcase{
+			name:             "validation is not a duplicate",
+			body:             `{"httpStatus": 400, "code": 1000, "message": "Custom field invalid"}`,
+			target:           ErrResourceAlreadyExists,
+			expectedIs:       false,
+			expectedResponse: ErrorResponse{HTTPStatus: 400, ErrorCode: ErrorValidationError, Message: "Custom field invalid"},
+		},
+		testcase{
+			name:             "validation",
+			body:             `{"httpStatus": 400, "code": 1000, "message": "Custom field invalid"}`,
+			target:           ErrValidationError,
+			expectedIs:       true,
+			expectedResponse: ErrorResponse{HTTPStatus: 400, ErrorCode: ErrorValidationError, Message: "Custom field invalid"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, tc.body)
+			}), 5*time.Second)
+			defer ts.Close()
+
+			_, err := c.GetContact(context.Background(), "foo", nil)
+			if err == nil {
+				t.Fatalf("Expected error did not occur")
+			}
+			if errors.Is(err, tc.target) != tc.expectedIs {
+				t.Fatalf("errors.Is(%#v, %#v) was not %t", err, tc.target, tc.expectedIs)
+			}
+			apiErr := &APIError{}
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Error (%#v) is not an APIError", err)
+			}
+			if !reflect.DeepEqual(tc.expectedResponse, apiErr.ErrorResponse) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", apiErr.ErrorResponse, tc.expectedResponse)
+			}
+			if err.Code() != fmt.Sprintf("%d", tc.expectedResponse.ErrorCode) {
+				t.Fatalf("Actual code (%s) did not match expected (%d)", err.Code(), tc.expectedResponse.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestUnit_APIErrorWrap(t *testing.T) {
+	cause := newAPIError(ErrorInternalError)
+	wrapped := ErrResourceNotFound.Wrap(cause)
+	if ErrResourceNotFound.GetCause() != nil {
+		t.Fatalf("Wrap modified the sentinel error")
+	}
+	if wrapped.GetCause() != cause || !errors.Is(wrapped, ErrResourceNotFound) {
+		t.Fatalf("Unexpected wrapped error (%#v)", wrapped)
+	}
+}
@@ -2,6 +2,17 @@ package getresponse
 
 import (
 	"net/url"
+
+	"github.com/healthimation/go-client/client"
+)
+
+// MAXRegion selects the API endpoint of a GetResponse MAX (enterprise) account
+type MAXRegion string
+
+// GetResponse MAX endpoints - https://apidocs.getresponse.com/v3/case-study/getresponse-max
+const (
+	MAXRegionUS MAXRegion = "https://api3.getresponse360.com/"
+	MAXRegionPL MAXRegion = "https://api3.getresponse360.pl/"
 )
 
 func findGetResponse(serviceName string, useTLS bool) (url.URL, error) {
@@ -11,3 +22,14 @@ func findGetResponse(serviceName string, useTLS bool) (url.URL, error) {
 	}
 	return *ret, err
 }
+
+// staticFinder always finds GR at baseURL
+func staticFinder(baseURL string) client.ServiceFinder {
+	return func(serviceName string, useTLS bool) (url.URL, error) {
+		ret, err := url.Parse(baseURL)
+		if err != nil || ret == nil {
+			return url.URL{}, err
+		}
+		return *ret, err
+	}
+}
@@ -0,0 +1,109 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// Verified reports whether GR can send from the address
+func (f FromField) Verified() bool {
+	return f.IsActive != nil && *f.IsActive == "true"
+}
+
+// Default reports whether the from field is the account's default
+func (f FromField) Default() bool {
+	return f.IsDefault != nil && *f.IsDefault == "true"
+}
+
+func (g *getResponseClient) GetFromFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]FromField, glitch.DataError) {
+	result := make([]FromField, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/from-fields", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateFromFields(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *FromFieldIterator {
+	it := &FromFieldIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]FromField, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/from-fields", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetFromField(ctx context.Context, ID string, fields []string) (FromField, glitch.DataError) {
+	result := FromField{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/from-fields/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) CreateFromField(ctx context.Context, fromField FromField) (FromField, glitch.DataError) {
+	result := FromField{}
+	err := g.do(ctx, http.MethodPost, "/v3/from-fields", nil, fromField, &result)
+	return result, err
+}
+
+func (g *getResponseClient) DeleteFromField(ctx context.Context, ID string, replacementID string) glitch.DataError {
+	query := url.Values{}
+	if replacementID != "" {
+		query.Set("fromFieldIdToReplaceWith", replacementID)
+	}
+	return g.do(ctx, http.MethodDelete, fmt.Sprintf("/v3/from-fields/%s", ID), query, nil, nil)
+}
+
+func (g *getResponseClient) SetDefaultFromField(ctx context.Context, ID string) (FromField, glitch.DataError) {
+	result := FromField{}
+	err := g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/from-fields/%s/default", ID), nil, nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) VerifiedFromField(ctx context.Context, ID string) (FromField, glitch.DataError) {
+	f, err := g.GetFromField(ctx, ID, nil)
+	if err != nil {
+		return f, err
+	}
+	if !f.Verified() {
+		return f, glitch.NewDataError(fmt.Errorf("from field %s has not been verified", ID), ErrorFromFieldNotVerified, "From field not verified")
+	}
+	return f, nil
+}
+
+// FromFieldIterator walks the pages of a from field listing.  Call Next until it returns false and then check Err.
+type FromFieldIterator struct {
+	p   *pager
+	buf []FromField
+	cur FromField
+}
+
+// Next advances to the next from field, fetching the next page when needed
+func (it *FromFieldIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// FromField returns the from field the iterator is positioned at
+func (it *FromFieldIterator) FromField() FromField {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *FromFieldIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *FromFieldIterator) Pagination() Pagination {
+	return it.p.pagination
+}
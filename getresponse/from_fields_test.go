@@ -0,0 +1,181 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_GetFromFields(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse []FromField
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/from-fields" || r.URL.Query().Get("query[email]") != "news@example.com" {
+					errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+					return
+				}
+				fmt.Fprint(w, `[{"fromFieldId": "f1", "email": "news@example.com", "name": "News", "isActive": "true", "isDefault": "false"}]`)
+			}),
+			expectedResponse: []FromField{FromField{
+				FromFieldID: "f1",
+				Email:       makeStringPtr("news@example.com"),
+				Name:        makeStringPtr("News"),
+				IsActive:    makeStringPtr("true"),
+				IsDefault:   makeStringPtr("false"),
+			}},
+		},
+		testcase{
+			name:            "unmarshal error",
+			handler:         http.HandlerFunc(undecodableHandler),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusUnauthorized, ErrorAuthenticationFailure),
+			expectedErrCode: makeStringPtr("1014"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetFromFields(context.Background(), map[string]string{"email": "news@example.com"}, nil, nil, 1, 10)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_FromFieldCalls(t *testing.T) {
+
+	type testcase struct {
+		name           string
+		call           func(c Client) (FromField, error)
+		expectedMethod string
+		expectedPath   string
+		expectedQuery  string
+	}
+
+	ctx := context.Background()
+	testcases := []testcase{
+		testcase{
+			name:           "get",
+			call:           func(c Client) (FromField, error) { return c.GetFromField(ctx, "f1", nil) },
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/v3/from-fields/f1",
+		},
+		testcase{
+			name: "create",
+			call: func(c Client) (FromField, error) {
+				return c.CreateFromField(ctx, FromField{Email: makeStringPtr("news@example.com"), Name: makeStringPtr("News")})
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v3/from-fields",
+		},
+		testcase{
+			name:           "set default",
+			call:           func(c Client) (FromField, error) { return c.SetDefaultFromField(ctx, "f1") },
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v3/from-fields/f1/default",
+		},
+		testcase{
+			name: "delete with replacement",
+			call: func(c Client) (FromField, error) {
+				if err := c.DeleteFromField(ctx, "f1", "f2"); err != nil {
+					return FromField{}, err
+				}
+				return FromField{FromFieldID: "f1"}, nil
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/v3/from-fields/f1",
+			expectedQuery:  "fromFieldIdToReplaceWith=f2",
+		},
+		testcase{
+			name: "delete",
+			call: func(c Client) (FromField, error) {
+				if err := c.DeleteFromField(ctx, "f1", ""); err != nil {
+					return FromField{}, err
+				}
+				return FromField{FromFieldID: "f1"}, nil
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/v3/from-fields/f1",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tc.expectedMethod || r.URL.Path != tc.expectedPath || r.URL.RawQuery != tc.expectedQuery {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+					errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+					return
+				}
+				if r.Method != http.MethodDelete {
+					fmt.Fprint(w, `{"fromFieldId": "f1"}`)
+				}
+			}), 5*time.Second)
+			defer ts.Close()
+			ret, err := tc.call(c)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if ret.FromFieldID != "f1" {
+				t.Fatalf("Unexpected from field %#v", ret)
+			}
+		})
+	}
+}
+
+func TestUnit_VerifiedFromField(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		response        string
+		expectedErrCode *string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:     "verified",
+			response: `{"fromFieldId": "f1", "isActive": "true"}`,
+		},
+		testcase{
+			name:            "not verified",
+			response:        `{"fromFieldId": "f1", "isActive": "false"}`,
+			expectedErrCode: makeStringPtr(ErrorFromFieldNotVerified),
+		},
+		testcase{
+			name:            "no status",
+			response:        `{"fromFieldId": "f1"}`,
+			expectedErrCode: makeStringPtr(ErrorFromFieldNotVerified),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tc.response)
+			}), 5*time.Second)
+			defer ts.Close()
+			ret, err := c.VerifiedFromField(context.Background(), "f1")
+			if checkDataError(t, err, tc.expectedErrCode) && !ret.Verified() {
+				t.Fatalf("Expected a verified from field, got %#v", ret)
+			}
+		})
+	}
+}
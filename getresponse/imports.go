@@ -0,0 +1,379 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// MaxImportContacts is the most contacts GR accepts in a single import
+const MaxImportContacts = 10000
+
+// Import statuses
+const (
+	ImportStatusToReview = "to_review"
+	ImportStatusApproved = "approved"
+	ImportStatusUploaded = "uploaded"
+	ImportStatusFinished = "finished"
+	ImportStatusRejected = "rejected"
+)
+
+// ContactSource streams contacts into ImportContacts.  A *ContactIterator is a ContactSource, so contacts can be
+// copied from one campaign or account to another.
+type ContactSource interface {
+	Next() bool
+	Contact() Contact
+	Err() glitch.DataError
+}
+
+// ContactSlice returns a ContactSource of contacts
+func ContactSlice(contacts []Contact) ContactSource {
+	return &sliceSource{contacts: contacts, i: -1}
+}
+
+type sliceSource struct {
+	contacts []Contact
+	i        int
+}
+
+func (s *sliceSource) Next() bool {
+	if s.i+1 >= len(s.contacts) {
+		return false
+	}
+	s.i++
+	return true
+}
+
+func (s *sliceSource) Contact() Contact {
+	return s.contacts[s.i]
+}
+
+func (s *sliceSource) Err() glitch.DataError {
+	return nil
+}
+
+// ImportRowError is a contact which was not imported
+type ImportRowError struct {
+	Row   int    // index of the contact in the source
+	Email string // email of the contact, when known
+	Err   glitch.DataError
+}
+
+// ImportReport is the outcome of ImportContacts
+type ImportReport struct {
+	Imports  []Import         // one per chunk of the source, in order
+	Failures []ImportRowError // in source order
+	Rows     int              // number of contacts read from the source
+
+	rows [][]int // the source rows of each import
+}
+
+func (g *getResponseClient) GetImports(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Import, glitch.DataError) {
+	result := make([]Import, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/imports", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateImports(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *ImportIterator {
+	it := &ImportIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Import, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/imports", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetImport(ctx context.Context, ID string, fields []string) (Import, glitch.DataError) {
+	result := Import{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/imports/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) CreateImport(ctx context.Context, campaignID string, contacts []Contact) (Import, glitch.DataError) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(contacts) == 0 || len(contacts) > MaxImportContacts {
+		return Import{}, newValidationError("Invalid import", fmt.Sprintf("an import takes 1 to %d contacts, not %d", MaxImportContacts, len(contacts)))
+	}
+	bodyObj, failures, err := g.importRequest(ctx, campaignID, contacts)
+	if err != nil {
+		return Import{}, err
+	}
+	if len(failures) > 0 {
+		problems := make([]string, 0, len(failures))
+		for _, f := range failures {
+			problems = append(problems, fmt.Sprintf("row %d: %s", f.Row, f.Err.Error()))
+		}
+		return Import{}, newValidationError("Invalid import", problems...)
+	}
+	return g.createImport(ctx, bodyObj)
+}
+
+func (g *getResponseClient) createImport(ctx context.Context, bodyObj createImportRequest) (Import, glitch.DataError) {
+	result := Import{}
+	err := g.do(ctx, http.MethodPost, "/v3/imports", nil, bodyObj, &result)
+	return result, err
+}
+
+// importRequest encodes contacts into import rows.  The returned failures index into contacts, the error is only set
+// when the custom field definitions couldn't be loaded.
+func (g *getResponseClient) importRequest(ctx context.Context, campaignID string, contacts []Contact) (createImportRequest, []ImportRowError, glitch.DataError) {
+	// load the definitions once for all rows rather than letting a failed load be retried per row
+	var byID, byName map[string]CustomFieldDefinition
+	for _, c := range contacts {
+		if g.needsDefinitions(c.CustomFieldValues) {
+			var err glitch.DataError
+			if byID, byName, err = g.customFieldDefinitions(ctx); err != nil {
+				return createImportRequest{}, nil, err
+			}
+			break
+		}
+	}
+
+	var failures []ImportRowError
+	type row struct {
+		contact      Contact
+		customFields map[string]string
+	}
+	rows := make([]row, 0, len(contacts))
+	columns := []string{}
+	seen := map[string]bool{}
+	withName := false
+
+	for i, c := range contacts {
+		email := ""
+		if c.Email != nil {
+			email = *c.Email
+		}
+		if strings.TrimSpace(email) == "" {
+			failures = append(failures, ImportRowError{Row: i, Err: newValidationError("Invalid contact", "email is required")})
+			continue
+		}
+		encoded := c.CustomFieldValues
+		if g.needsDefinitions(encoded) {
+			var err glitch.DataError
+			if encoded, err = encodeCustomFieldsWith(encoded, byID, byName); err != nil {
+				failures = append(failures, ImportRowError{Row: i, Email: email, Err: err})
+				continue
+			}
+		}
+		r := row{contact: c, customFields: map[string]string{}}
+		for _, cf := range encoded {
+			if !seen[cf.CustomFieldID] {
+				seen[cf.CustomFieldID] = true
+				columns = append(columns, cf.CustomFieldID)
+			}
+			// an import cell holds one value so multi-value fields are comma separated
+			r.customFields[cf.CustomFieldID] = strings.Join(cf.Value, ",")
+		}
+		withName = withName || c.Name != nil
+		rows = append(rows, r)
+	}
+
+	ret := createImportRequest{
+		Campaign:     Campaign{CampaignID: campaignID},
+		FieldMapping: []string{"email"},
+		Contacts:     make([][]string, 0, len(rows)),
+	}
+	if withName {
+		ret.FieldMapping = append(ret.FieldMapping, "name")
+	}
+	ret.FieldMapping = append(ret.FieldMapping, columns...)
+	for _, r := range rows {
+		cells := []string{*r.contact.Email}
+		if withName {
+			name := ""
+			if r.contact.Name != nil {
+				name = *r.contact.Name
+			}
+			cells = append(cells, name)
+		}
+		for _, id := range columns {
+			cells = append(cells, r.customFields[id])
+		}
+		ret.Contacts = append(ret.Contacts, cells)
+	}
+	return ret, failures, nil
+}
+
+func (g *getResponseClient) WaitForImport(ctx context.Context, ID string) (Import, glitch.DataError) {
+	result := Import{}
+	err := poll(ctx, g.pollPolicy, func(ctx context.Context) (bool, glitch.DataError) {
+		i, err := g.GetImport(ctx, ID, nil)
+		if err != nil {
+			return false, err
+		}
+		result = i
+		return importDone(i), nil
+	})
+	return result, err
+}
+
+func importDone(i Import) bool {
+	if i.Status != nil && (*i.Status == ImportStatusFinished || *i.Status == ImportStatusRejected) {
+		return true
+	}
+	return i.FinishedOn != nil && !i.FinishedOn.IsZero()
+}
+
+func (g *getResponseClient) ImportContacts(ctx context.Context, campaignID string, source ContactSource) (ImportReport, glitch.DataError) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	report := ImportReport{}
+	chunk := make([]Contact, 0, g.importSize)
+	first := 0
+
+	flush := func() glitch.DataError {
+		if len(chunk) == 0 {
+			return nil
+		}
+		defer func() {
+			first += len(chunk)
+			chunk = chunk[:0]
+		}()
+
+		bodyObj, failures, err := g.importRequest(ctx, campaignID, chunk)
+		if err != nil {
+			all := make([]int, 0, len(chunk))
+			for i := range chunk {
+				all = append(all, first+i)
+			}
+			report.addFailures(chunk, first, all, err)
+			if ctx.Err() != nil {
+				return err
+			}
+			return nil
+		}
+		sent := make([]int, 0, len(bodyObj.Contacts))
+		failed := map[int]bool{}
+		for _, f := range failures {
+			failed[f.Row] = true
+			f.Row += first
+			report.Failures = append(report.Failures, f)
+		}
+		for i := range chunk {
+			if !failed[i] {
+				sent = append(sent, first+i)
+			}
+		}
+		if ctx.Err() != nil {
+			err := glitch.NewDataError(ctx.Err(), ErrorCanceled, "Import was canceled")
+			report.addFailures(chunk, first, sent, err)
+			return err
+		}
+		if len(sent) == 0 {
+			return nil
+		}
+
+		i, err := g.createImport(ctx, bodyObj)
+		if err != nil {
+			report.addFailures(chunk, first, sent, err)
+			if ctx.Err() != nil {
+				return err
+			}
+			return nil
+		}
+		report.Imports = append(report.Imports, i)
+		report.rows = append(report.rows, sent)
+		return nil
+	}
+
+	for source.Next() {
+		chunk = append(chunk, source.Contact())
+		report.Rows++
+		if len(chunk) == g.importSize {
+			if err := flush(); err != nil {
+				report.sortFailures()
+				return report, err
+			}
+		}
+	}
+	// import what was read before a failing source so the report accounts for every row
+	err := flush()
+	if sourceErr := source.Err(); sourceErr != nil {
+		err = sourceErr
+	}
+	report.sortFailures()
+	return report, err
+}
+
+func (g *getResponseClient) ImportContactsAndWait(ctx context.Context, campaignID string, source ContactSource) (ImportReport, glitch.DataError) {
+	report, err := g.ImportContacts(ctx, campaignID, source)
+	if err != nil {
+		return report, err
+	}
+	for n, i := range report.Imports {
+		done, err := g.WaitForImport(ctx, i.ImportID)
+		if err != nil {
+			return report, err
+		}
+		report.Imports[n] = done
+		if done.Status != nil && *done.Status == ImportStatusRejected {
+			rejected := glitch.NewDataError(fmt.Errorf("import %s was rejected", i.ImportID), ErrorImportRejected, "Import was rejected")
+			for _, row := range report.rows[n] {
+				report.Failures = append(report.Failures, ImportRowError{Row: row, Err: rejected})
+			}
+		}
+	}
+	report.sortFailures()
+	return report, nil
+}
+
+// addFailures reports the rows of chunk that were sent in a failed import
+func (r *ImportReport) addFailures(chunk []Contact, first int, sent []int, err glitch.DataError) {
+	for _, row := range sent {
+		f := ImportRowError{Row: row, Err: err}
+		if e := chunk[row-first].Email; e != nil {
+			f.Email = *e
+		}
+		r.Failures = append(r.Failures, f)
+	}
+}
+
+// sortFailures puts the failures in source order, they are collected per import
+func (r *ImportReport) sortFailures() {
+	sort.SliceStable(r.Failures, func(i, j int) bool { return r.Failures[i].Row < r.Failures[j].Row })
+}
+
+// ImportIterator walks the pages of an import listing.  Call Next until it returns false and then check Err.
+type ImportIterator struct {
+	p   *pager
+	buf []Import
+	cur Import
+}
+
+// Next advances to the next import, fetching the next page when needed
+func (it *ImportIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Import returns the import the iterator is positioned at
+func (it *ImportIterator) Import() Import {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ImportIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *ImportIterator) Pagination() Pagination {
+	return it.p.pagination
+}
@@ -0,0 +1,263 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUnit_CreateImport(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		contacts        []Contact
+		expectedErrCode *string
+		expectedBody    *createImportRequest
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			contacts: []Contact{
+				Contact{Email: makeStringPtr("a@example.com"), Name: makeStringPtr("A"), CustomFieldValues: []CustomField{CustomField{CustomFieldID: "cf1", Value: []string{"x", "y"}}}},
+				Contact{Email: makeStringPtr("b@example.com"), CustomFieldValues: []CustomField{CustomField{CustomFieldID: "cf2", Value: []string{"z"}}}},
+			},
+			expectedBody: &createImportRequest{
+				Campaign:     Campaign{CampaignID: "V"},
+				FieldMapping: []string{"email", "name", "cf1", "cf2"},
+				Contacts: [][]string{
+					[]string{"a@example.com", "A", "x,y", ""},
+					[]string{"b@example.com", "", "", "z"},
+				},
+			},
+		},
+		testcase{
+			name:         "without names",
+			contacts:     []Contact{Contact{Email: makeStringPtr("a@example.com")}},
+			expectedBody: &createImportRequest{Campaign: Campaign{CampaignID: "V"}, FieldMapping: []string{"email"}, Contacts: [][]string{[]string{"a@example.com"}}},
+		},
+		testcase{
+			name:            "missing email",
+			contacts:        []Contact{Contact{Email: makeStringPtr("a@example.com")}, Contact{Name: makeStringPtr("B")}},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "no contacts",
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "too many contacts",
+			contacts:        make([]Contact, MaxImportContacts+1),
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body *createImportRequest
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v3/imports" {
+					errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+					return
+				}
+				body = &createImportRequest{}
+				b, _ := ioutil.ReadAll(r.Body)
+				json.Unmarshal(b, body)
+				fmt.Fprint(w, `{"importId": "imp1", "status": "uploaded"}`)
+			}), 5*time.Second)
+			defer ts.Close()
+			ret, err := c.CreateImport(context.Background(), "V", tc.contacts)
+			if !checkDataError(t, err, tc.expectedErrCode) {
+				if body != nil {
+					t.Fatalf("Expected nothing to be sent")
+				}
+				return
+			}
+			if !reflect.DeepEqual(tc.expectedBody, body) {
+				t.Fatalf("Actual body (%#v) did not match expected (%#v)", body, tc.expectedBody)
+			}
+			if ret.ImportID != "imp1" || *ret.Status != ImportStatusUploaded {
+				t.Fatalf("Unexpected import %#v", ret)
+			}
+		})
+	}
+}
+
+func TestUnit_WaitForImport(t *testing.T) {
+	gets := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if gets < 3 {
+			fmt.Fprint(w, `{"importId": "imp1", "status": "approved"}`)
+			return
+		}
+		fmt.Fprint(w, `{"importId": "imp1", "status": "finished", "statistics": {"uploaded": 2, "invalid": 1, "updated": 0, "addedToList": 1}}`)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL), WithPollPolicy(testPollPolicy))
+
+	ret, err := c.WaitForImport(context.Background(), "imp1")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if gets != 3 || !reflect.DeepEqual(&ImportStatistics{Uploaded: 2, Invalid: 1, AddedToList: 1}, ret.Statistics) {
+		t.Fatalf("Unexpected import %#v after %d polls", ret, gets)
+	}
+}
+
+func TestUnit_ImportContacts(t *testing.T) {
+	contacts := make([]Contact, 0, 7)
+	for i := 0; i < 7; i++ {
+		contacts = append(contacts, Contact{Email: makeStringPtr(fmt.Sprintf("c%d@example.com", i))})
+	}
+	contacts[1].Email = nil
+
+	var mu sync.Mutex
+	sent := [][]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost:
+			body := createImportRequest{}
+			b, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(b, &body)
+			emails := []string{}
+			for _, row := range body.Contacts {
+				emails = append(emails, row[0])
+			}
+			sent = append(sent, emails)
+			if emails[0] == "c4@example.com" {
+				errorHandler(http.StatusBadRequest, ErrorValidationError)(w, r)
+				return
+			}
+			fmt.Fprintf(w, `{"importId": "imp%d", "status": "uploaded"}`, len(sent))
+		case r.URL.Path == "/v3/imports/imp4":
+			fmt.Fprint(w, `{"importId": "imp4", "status": "rejected"}`)
+		default:
+			fmt.Fprintf(w, `{"importId": "%s", "status": "finished"}`, r.URL.Path[len("/v3/imports/"):])
+		}
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL), WithPollPolicy(testPollPolicy), WithImportChunkSize(2))
+
+	report, err := c.ImportContactsAndWait(context.Background(), "V", ContactSlice(contacts))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expectedSent := [][]string{
+		[]string{"c0@example.com"},
+		[]string{"c2@example.com", "c3@example.com"},
+		[]string{"c4@example.com", "c5@example.com"},
+		[]string{"c6@example.com"},
+	}
+	if !reflect.DeepEqual(expectedSent, sent) {
+		t.Fatalf("Actual imports (%v) did not match expected (%v)", sent, expectedSent)
+	}
+	if report.Rows != 7 || len(report.Imports) != 3 || *report.Imports[1].Status != ImportStatusFinished {
+		t.Fatalf("Unexpected report %#v", report)
+	}
+
+	// row 1 has no email, rows 4 and 5 failed to import and row 6 was rejected
+	expectedFailures := []struct {
+		row  int
+		code string
+	}{{1, ErrorLocalValidation}, {4, "1000"}, {5, "1000"}, {6, ErrorImportRejected}}
+	if len(report.Failures) != len(expectedFailures) {
+		t.Fatalf("Unexpected failures %#v", report.Failures)
+	}
+	for i, f := range expectedFailures {
+		if report.Failures[i].Row != f.row || report.Failures[i].Err.Code() != f.code {
+			t.Fatalf("Failure %d (%#v) did not match expected row %d with %s", i, report.Failures[i], f.row, f.code)
+		}
+	}
+	if report.Failures[1].Email != "c4@example.com" {
+		t.Fatalf("Expected the failure to carry the email, got %#v", report.Failures[1])
+	}
+}
+
+func TestUnit_ImportContactsFromIterator(t *testing.T) {
+	imported := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body := createImportRequest{}
+			b, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(b, &body)
+			imported += len(body.Contacts)
+			fmt.Fprint(w, `{"importId": "imp1"}`)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			errorHandler(http.StatusServiceUnavailable, ErrorInternalError)(w, r)
+			return
+		}
+		w.Header().Set("TotalPages", "2")
+		w.Header().Set("CurrentPage", "1")
+		fmt.Fprint(w, `[{"email": "a@example.com"}, {"email": "b@example.com"}]`)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	report, err := c.ImportContacts(context.Background(), "W", c.IterateContacts(context.Background(), nil, nil, nil, 2, nil))
+	checkDataError(t, err, makeStringPtr("1"))
+	if report.Rows != 2 || imported+len(report.Failures) != report.Rows || len(report.Imports) != 1 {
+		t.Fatalf("Expected the rows read before a failing source to be imported, got %#v after %d rows", report, imported)
+	}
+}
+
+func TestUnit_ImportContactsNilContext(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"importId": "imp1"}`)
+	}), 5*time.Second)
+	defer ts.Close()
+
+	contacts := []Contact{Contact{Email: makeStringPtr("a@example.com")}}
+	if _, err := c.CreateImport(nil, "V", contacts); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	report, err := c.ImportContacts(nil, "V", ContactSlice(contacts))
+	if err != nil || len(report.Imports) != 1 {
+		t.Fatalf("Unexpected report %#v (%#v)", report, err)
+	}
+}
+
+func TestUnit_ImportContactsDefinitionsFailure(t *testing.T) {
+	loads, posts := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/custom-fields" {
+			loads++
+			errorHandler(http.StatusInternalServerError, ErrorInternalError)(w, r)
+			return
+		}
+		posts++
+		fmt.Fprint(w, `{"importId": "imp1"}`)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL), WithRetryPolicy(RetryPolicy{}))
+
+	contacts := make([]Contact, 0, 50)
+	for i := 0; i < 50; i++ {
+		contacts = append(contacts, Contact{
+			Email:             makeStringPtr(fmt.Sprintf("c%d@example.com", i)),
+			CustomFieldValues: []CustomField{NamedCustomFieldValue("plan", "pro")},
+		})
+	}
+	report, err := c.ImportContacts(context.Background(), "V", ContactSlice(contacts))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if loads != 1 || posts != 0 {
+		t.Fatalf("Expected one definitions load and no import, got %d loads and %d imports", loads, posts)
+	}
+	if len(report.Failures) != len(contacts) || report.Failures[0].Err.Code() != "1" {
+		t.Fatalf("Expected every row of the chunk to fail, got %#v", report.Failures)
+	}
+}
@@ -0,0 +1,100 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// Newsletter types
+const (
+	NewsletterTypeBroadcast = "broadcast"
+	NewsletterTypeDraft     = "draft"
+)
+
+func (g *getResponseClient) GetNewsletters(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Newsletter, glitch.DataError) {
+	result := make([]Newsletter, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/newsletters", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateNewsletters(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *NewsletterIterator {
+	it := &NewsletterIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Newsletter, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/newsletters", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetNewsletter(ctx context.Context, ID string, fields []string) (Newsletter, glitch.DataError) {
+	result := Newsletter{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/newsletters/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) CreateNewsletter(ctx context.Context, newsletter Newsletter) (Newsletter, glitch.DataError) {
+	result := Newsletter{}
+	err := g.do(ctx, http.MethodPost, "/v3/newsletters", nil, newsletter, &result)
+	return result, err
+}
+
+func (g *getResponseClient) SendNewsletterDraft(ctx context.Context, ID string, sendOn *time.Time, sendSettings NewsletterSendSettings) (Newsletter, glitch.DataError) {
+	result := Newsletter{}
+	bodyObj := sendNewsletterDraftRequest{MessageID: ID, SendSettings: sendSettings}
+	if sendOn != nil {
+		bodyObj.SendOn = NewTimestamp(*sendOn)
+	}
+	err := g.do(ctx, http.MethodPost, "/v3/newsletters/send-draft", nil, bodyObj, &result)
+	return result, err
+}
+
+func (g *getResponseClient) DeleteNewsletter(ctx context.Context, ID string) glitch.DataError {
+	return g.do(ctx, http.MethodDelete, fmt.Sprintf("/v3/newsletters/%s", ID), nil, nil, nil)
+}
+
+func (g *getResponseClient) CancelNewsletter(ctx context.Context, ID string) (Newsletter, glitch.DataError) {
+	result := Newsletter{}
+	err := g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/newsletters/%s/cancel", ID), nil, nil, &result)
+	return result, err
+}
+
+// NewsletterIterator walks the pages of a newsletter listing.  Call Next until it returns false and then check Err.
+type NewsletterIterator struct {
+	p   *pager
+	buf []Newsletter
+	cur Newsletter
+}
+
+// Next advances to the next newsletter, fetching the next page when needed
+func (it *NewsletterIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Newsletter returns the newsletter the iterator is positioned at
+func (it *NewsletterIterator) Newsletter() Newsletter {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *NewsletterIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *NewsletterIterator) Pagination() Pagination {
+	return it.p.pagination
+}
@@ -0,0 +1,168 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_GetNewsletters(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse []Newsletter
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/newsletters" || r.URL.Query().Get("query[subject]") != "digest" {
+					errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+					return
+				}
+				fmt.Fprint(w, `[{"newsletterId": "n1", "subject": "Weekly digest", "type": "broadcast", "sendOn": "2018-01-15T10:00:00+0000"}]`)
+			}),
+			expectedResponse: []Newsletter{Newsletter{
+				NewsletterID: "n1",
+				Subject:      makeStringPtr("Weekly digest"),
+				Type:         makeStringPtr(NewsletterTypeBroadcast),
+				SendOn:       NewTimestamp(time.Date(2018, 1, 15, 10, 0, 0, 0, time.UTC)),
+			}},
+		},
+		testcase{
+			name:            "unmarshal error",
+			handler:         http.HandlerFunc(undecodableHandler),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusUnauthorized, ErrorAuthenticationFailure),
+			expectedErrCode: makeStringPtr("1014"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetNewsletters(context.Background(), map[string]string{"subject": "digest"}, nil, nil, 1, 10)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_NewsletterCalls(t *testing.T) {
+
+	type testcase struct {
+		name           string
+		call           func(c Client) (Newsletter, error)
+		expectedMethod string
+		expectedPath   string
+		expectedBody   string
+	}
+
+	ctx := context.Background()
+	sendOn := time.Date(2018, 1, 15, 10, 0, 0, 0, time.UTC)
+	settings := NewsletterSendSettings{SelectedCampaigns: []string{"V"}, TimeTravel: makeStringPtr("true")}
+	testcases := []testcase{
+		testcase{
+			name:           "get",
+			call:           func(c Client) (Newsletter, error) { return c.GetNewsletter(ctx, "n1", nil) },
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/v3/newsletters/n1",
+		},
+		testcase{
+			name: "create",
+			call: func(c Client) (Newsletter, error) {
+				return c.CreateNewsletter(ctx, Newsletter{
+					Subject:      makeStringPtr("Weekly digest"),
+					FromField:    &FromFieldReference{FromFieldID: "f1"},
+					Campaign:     &Campaign{CampaignID: "V"},
+					Content:      &NewsletterContent{HTML: makeStringPtr("<b>hi</b>"), Plain: makeStringPtr("hi")},
+					SendOn:       NewTimestamp(sendOn),
+					SendSettings: &settings,
+				})
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v3/newsletters",
+			expectedBody: `{"subject":"Weekly digest","fromField":{"fromFieldId":"f1"},"campaign":{"campaignId":"V"},` +
+				`"content":{"html":"\u003cb\u003ehi\u003c/b\u003e","plain":"hi"},"sendOn":"2018-01-15T10:00:00+0000",` +
+				`"sendSettings":{"selectedCampaigns":["V"],"timeTravel":"true"}}`,
+		},
+		testcase{
+			name:           "send draft",
+			call:           func(c Client) (Newsletter, error) { return c.SendNewsletterDraft(ctx, "n1", &sendOn, settings) },
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v3/newsletters/send-draft",
+			expectedBody:   `{"messageId":"n1","sendOn":"2018-01-15T10:00:00+0000","sendSettings":{"selectedCampaigns":["V"],"timeTravel":"true"}}`,
+		},
+		testcase{
+			name:           "cancel",
+			call:           func(c Client) (Newsletter, error) { return c.CancelNewsletter(ctx, "n1") },
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v3/newsletters/n1/cancel",
+		},
+		testcase{
+			name: "delete",
+			call: func(c Client) (Newsletter, error) {
+				if err := c.DeleteNewsletter(ctx, "n1"); err != nil {
+					return Newsletter{}, err
+				}
+				return Newsletter{NewsletterID: "n1"}, nil
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/v3/newsletters/n1",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				if r.Method != tc.expectedMethod || r.URL.Path != tc.expectedPath || (tc.expectedBody != "" && string(b) != tc.expectedBody) {
+					t.Errorf("Unexpected request %s %s %s", r.Method, r.URL.Path, b)
+					errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+					return
+				}
+				if r.Method != http.MethodDelete {
+					fmt.Fprint(w, `{"newsletterId": "n1"}`)
+				}
+			}), 5*time.Second)
+			defer ts.Close()
+			ret, err := tc.call(c)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if ret.NewsletterID != "n1" {
+				t.Fatalf("Unexpected newsletter %#v", ret)
+			}
+		})
+	}
+}
+
+func TestUnit_NewsletterAlreadySending(t *testing.T) {
+	c, ts := testClient(errorHandler(http.StatusBadRequest, ErrorMessageAlreadySending), 5*time.Second)
+	defer ts.Close()
+
+	_, err := c.CancelNewsletter(context.Background(), "n1")
+	if !errors.Is(err, ErrMessageAlreadySending) {
+		t.Fatalf("Expected cancel to fail with ErrMessageAlreadySending, got %#v", err)
+	}
+	err = c.DeleteNewsletter(context.Background(), "n1")
+	if !errors.Is(err, ErrMessageAlreadySending) || errors.Is(err, ErrResourceNotFound) {
+		t.Fatalf("Expected delete to fail with ErrMessageAlreadySending, got %#v", err)
+	}
+	if err.Code() != "1011" {
+		t.Fatalf("Unexpected error code %s", err.Code())
+	}
+}
@@ -0,0 +1,245 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/healthimation/go-client/client"
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// OAuth endpoints of GR retail accounts, MAX accounts use their own - https://apidocs.getresponse.com/v3/authentication/oauth
+const (
+	DefaultOAuthAuthURL  = "https://app.getresponse.com/oauth2_authorize.html"
+	DefaultOAuthTokenURL = "https://api.getresponse.com/v3/token"
+)
+
+// tokens are refreshed this long before they expire
+const tokenExpiryDelta = 10 * time.Second
+
+// Token is an OAuth 2.0 token issued by GR
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresIn    int64     `json:"expires_in,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"` // set from ExpiresIn when the token is issued
+}
+
+// Valid reports whether the token can be used without refreshing it
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.Expiry))
+}
+
+// TokenSource supplies the bearer token for a request
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, glitch.DataError)
+}
+
+type staticTokenSource struct {
+	t *Token
+}
+
+// StaticTokenSource always returns t, it never refreshes it
+func StaticTokenSource(t *Token) TokenSource {
+	return staticTokenSource{t: t}
+}
+
+func (s staticTokenSource) Token(ctx context.Context) (*Token, glitch.DataError) {
+	return s.t, nil
+}
+
+// OAuthConfig describes an application registered with GR for the authorization code flow
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string       // defaults to DefaultOAuthAuthURL
+	TokenURL     string       // defaults to DefaultOAuthTokenURL
+	HTTPClient   *http.Client // defaults to http.DefaultClient
+}
+
+// AuthCodeURL returns the URL to send the user to so they can grant access to their account
+func (c OAuthConfig) AuthCodeURL(state string) string {
+	authURL := c.AuthURL
+	if authURL == "" {
+		authURL = DefaultOAuthAuthURL
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", c.ClientID)
+	query.Set("state", state)
+	if c.RedirectURL != "" {
+		query.Set("redirect_uri", c.RedirectURL)
+	}
+
+	sep := "?"
+	if strings.Contains(authURL, "?") {
+		sep = "&"
+	}
+	return authURL + sep + query.Encode()
+}
+
+// Exchange trades the code GR redirected back with for a token
+func (c OAuthConfig) Exchange(ctx context.Context, code string) (*Token, glitch.DataError) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	if c.RedirectURL != "" {
+		form.Set("redirect_uri", c.RedirectURL)
+	}
+	return c.requestToken(ctx, form)
+}
+
+// Refresh trades a refresh token for a new token
+func (c OAuthConfig) Refresh(ctx context.Context, refreshToken string) (*Token, glitch.DataError) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	return c.requestToken(ctx, form)
+}
+
+// TokenSource returns a TokenSource which starts with t and refreshes it when it expires.  onRefresh, if not nil,
+// is called with every new token so it can be persisted.  The source is safe for concurrent use.
+func (c OAuthConfig) TokenSource(t *Token, onRefresh func(*Token)) TokenSource {
+	return &refreshingTokenSource{config: c, t: t, onRefresh: onRefresh}
+}
+
+func (c OAuthConfig) requestToken(ctx context.Context, form url.Values) (*Token, glitch.DataError) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultOAuthTokenURL
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, glitch.NewDataError(err, client.ErrorRequestCreation, "Error creating token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.ClientID, c.ClientSecret)
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, glitch.NewDataError(err, client.ErrorRequestError, "Could not make the token request")
+	}
+	defer resp.Body.Close()
+
+	ret, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, glitch.NewDataError(err, client.ErrorDecodingResponse, "Could not read token response body")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		errRet := struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}{}
+		json.Unmarshal(ret, &errRet)
+		return nil, glitch.NewDataError(fmt.Errorf("%s: %s", errRet.Error, errRet.ErrorDescription), ErrorOAuth, fmt.Sprintf("Token request failed with status %d", resp.StatusCode))
+	}
+
+	t := &Token{}
+	if err := json.Unmarshal(ret, t); err != nil {
+		return nil, glitch.NewDataError(err, client.ErrorDecodingResponse, fmt.Sprintf("Could not unmarshal token response: %s", ret))
+	}
+	if t.AccessToken == "" {
+		return nil, glitch.NewDataError(errors.New("missing access_token"), ErrorOAuth, "Token response did not contain an access token")
+	}
+	if t.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return t, nil
+}
+
+type refreshingTokenSource struct {
+	mu        sync.Mutex
+	config    OAuthConfig
+	t         *Token
+	onRefresh func(*Token)
+}
+
+func (s *refreshingTokenSource) Token(ctx context.Context) (*Token, glitch.DataError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.t.Valid() {
+		return s.t, nil
+	}
+	if s.t == nil || s.t.RefreshToken == "" {
+		return nil, glitch.NewDataError(errors.New("missing refresh_token"), ErrorOAuth, "Token expired and there is no refresh token")
+	}
+
+	t, err := s.config.Refresh(ctx, s.t.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = s.t.RefreshToken
+	}
+	s.t = t
+	if s.onRefresh != nil {
+		s.onRefresh(t)
+	}
+	return t, nil
+}
+
+type credentialsKey struct{}
+
+type credentials struct {
+	apiKey      string
+	tokenSource TokenSource
+}
+
+// ContextWithAPIKey makes requests made with the returned context authenticate with apiKey, overriding the
+// client's own credentials
+func ContextWithAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, credentials{apiKey: apiKey})
+}
+
+// ContextWithTokenSource makes requests made with the returned context authenticate with a bearer token from
+// ts, overriding the client's own credentials
+func ContextWithTokenSource(ctx context.Context, ts TokenSource) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, credentials{tokenSource: ts})
+}
+
+// authorize sets the auth header from the context's credentials, falling back to the client's
+func (g *getResponseClient) authorize(ctx context.Context, h http.Header) glitch.DataError {
+	creds := credentials{apiKey: g.apiKey, tokenSource: g.tokenSource}
+	if ctx != nil {
+		if c, ok := ctx.Value(credentialsKey{}).(credentials); ok {
+			creds = c
+		}
+	}
+
+	if creds.tokenSource == nil {
+		h.Set("X-Auth-Token", fmt.Sprintf("api-key %s", creds.apiKey))
+		return nil
+	}
+
+	t, err := creds.tokenSource.Token(ctx)
+	if err != nil {
+		return err
+	}
+	if t == nil || t.AccessToken == "" {
+		return glitch.NewDataError(errors.New("missing access token"), ErrorOAuth, "Token source returned no access token")
+	}
+	h.Set("Authorization", fmt.Sprintf("Bearer %s", t.AccessToken))
+	return nil
+}
@@ -0,0 +1,194 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestUnit_OAuthAuthCodeURL(t *testing.T) {
+	c := OAuthConfig{ClientID: "id", RedirectURL: "https://example.com/cb"}
+	u, err := url.Parse(c.AuthCodeURL("xyz"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	q := u.Query()
+	if u.Host != "app.getresponse.com" || q.Get("response_type") != "code" || q.Get("client_id") != "id" || q.Get("state") != "xyz" || q.Get("redirect_uri") != "https://example.com/cb" {
+		t.Fatalf("Unexpected auth code url (%s)", u.String())
+	}
+}
+
+func TestUnit_OAuthExchange(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		handler         http.HandlerFunc
+		expectedToken   string
+		expectedErrCode *string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, pass, ok := r.BasicAuth()
+				if !ok || user != "id" || pass != "secret" || r.FormValue("grant_type") != "authorization_code" || r.FormValue("code") != "abc" {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error": "invalid_request"}`)
+					return
+				}
+				fmt.Fprint(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 86400, "refresh_token": "refresh"}`)
+			}),
+			expectedToken: "token",
+		},
+		testcase{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error": "invalid_grant", "error_description": "Authorization code doesn't exist or is invalid"}`)
+			}),
+			expectedErrCode: makeStringPtr(ErrorOAuth),
+		},
+		testcase{
+			name: "unmarshal error",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"not json"`)
+			}),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_RESPONSE"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(tc.handler)
+			defer ts.Close()
+			c := OAuthConfig{ClientID: "id", ClientSecret: "secret", TokenURL: ts.URL}
+			tok, err := c.Exchange(context.Background(), "abc")
+			if err == nil && tc.expectedErrCode == nil {
+				if tok.AccessToken != tc.expectedToken || !tok.Valid() {
+					t.Fatalf("Actual token (%#v) did not match expected (%s)", tok, tc.expectedToken)
+				}
+			} else {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+				if err.Error() == "" {
+					t.Fatalf("Error has no message")
+				}
+			}
+		})
+	}
+}
+
+func TestUnit_OAuthTokenSourceRefresh(t *testing.T) {
+	refreshes := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "refresh_token" || r.FormValue("refresh_token") != "refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		refreshes++
+		fmt.Fprintf(w, `{"access_token": "token%d", "expires_in": 3600}`, refreshes)
+	}))
+	defer ts.Close()
+
+	persisted := []*Token{}
+	c := OAuthConfig{ClientID: "id", ClientSecret: "secret", TokenURL: ts.URL}
+	source := c.TokenSource(&Token{AccessToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)}, func(t *Token) {
+		persisted = append(persisted, t)
+	})
+
+	for i := 0; i < 2; i++ {
+		tok, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+		if tok.AccessToken != "token1" || tok.RefreshToken != "refresh" {
+			t.Fatalf("Actual token (%#v) was not the refreshed one", tok)
+		}
+	}
+	if refreshes != 1 || len(persisted) != 1 {
+		t.Fatalf("Token refreshed %d times and persisted %d times, expected once", refreshes, len(persisted))
+	}
+}
+
+func TestUnit_Credentials(t *testing.T) {
+	var gotAPIKey, gotBearer string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAPIKey = r.Header.Get("X-Auth-Token")
+		gotBearer = r.Header.Get("Authorization")
+	}))
+	defer ts.Close()
+
+	type testcase struct {
+		name            string
+		opts            []Option
+		ctx             context.Context
+		expectedAPIKey  string
+		expectedBearer  string
+		expectedErrCode *string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:           "api key",
+			opts:           []Option{WithAPIKey("key")},
+			ctx:            context.Background(),
+			expectedAPIKey: "api-key key",
+		},
+		testcase{
+			name:           "token source",
+			opts:           []Option{WithAPIKey("key"), WithTokenSource(StaticTokenSource(&Token{AccessToken: "token"}))},
+			ctx:            context.Background(),
+			expectedBearer: "Bearer token",
+		},
+		testcase{
+			name:           "token source from context",
+			opts:           []Option{WithAPIKey("key")},
+			ctx:            ContextWithTokenSource(context.Background(), StaticTokenSource(&Token{AccessToken: "tenant"})),
+			expectedBearer: "Bearer tenant",
+		},
+		testcase{
+			name:           "api key from context",
+			opts:           []Option{WithTokenSource(StaticTokenSource(&Token{AccessToken: "token"}))},
+			ctx:            ContextWithAPIKey(context.Background(), "tenant"),
+			expectedAPIKey: "api-key tenant",
+		},
+		testcase{
+			name:            "nil token",
+			opts:            []Option{WithTokenSource(StaticTokenSource(nil))},
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr(ErrorOAuth),
+		},
+		testcase{
+			name:            "empty access token",
+			opts:            []Option{WithTokenSource(StaticTokenSource(&Token{}))},
+			ctx:             context.Background(),
+			expectedErrCode: makeStringPtr(ErrorOAuth),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotAPIKey, gotBearer = "", ""
+			c := NewClientWithOptions(append(tc.opts, WithBaseURL(ts.URL))...)
+			err := c.DeleteContact(tc.ctx, "foo", "", "")
+			if !checkDataError(t, err, tc.expectedErrCode) {
+				return
+			}
+			if gotAPIKey != tc.expectedAPIKey || gotBearer != tc.expectedBearer {
+				t.Fatalf("Actual auth headers (%q, %q) did not match expected (%q, %q)", gotAPIKey, gotBearer, tc.expectedAPIKey, tc.expectedBearer)
+			}
+		})
+	}
+}
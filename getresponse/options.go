@@ -0,0 +1,137 @@
+package getresponse
+
+import (
+	"net/http"
+	"time"
+)
+
+// DefaultUserAgent is sent with every request unless WithUserAgent overrides it
+const DefaultUserAgent = "go-getresponse"
+
+// Option configures the client returned by NewClient or NewClientWithOptions
+type Option func(*getResponseClient)
+
+// Logger receives a line for every request the client makes.  *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Hooks are called around every round trip, including retries
+type Hooks struct {
+	// BeforeRequest may inspect or modify the request before it is sent
+	BeforeRequest func(req *http.Request)
+	// AfterResponse is called with the response or the transport error.  It must not read or close the body.
+	AfterResponse func(req *http.Request, resp *http.Response, err error)
+}
+
+// WithAPIKey authenticates requests with a GR api key
+func WithAPIKey(apiKey string) Option {
+	return func(g *getResponseClient) {
+		g.apiKey = apiKey
+	}
+}
+
+// WithTokenSource authenticates requests with OAuth bearer tokens from ts instead of an api key
+func WithTokenSource(ts TokenSource) Option {
+	return func(g *getResponseClient) {
+		g.tokenSource = ts
+	}
+}
+
+// WithTimeout sets the timeout of each round trip, applied on top of the client given to WithHTTPClient
+func WithTimeout(timeout time.Duration) Option {
+	return func(g *getResponseClient) {
+		g.timeout = timeout
+	}
+}
+
+// WithHTTPClient makes requests with hc, e.g. one configured for a proxy or mTLS egress
+func WithHTTPClient(hc *http.Client) Option {
+	return func(g *getResponseClient) {
+		g.httpClient = hc
+	}
+}
+
+// WithTransport makes requests through rt
+func WithTransport(rt http.RoundTripper) Option {
+	return func(g *getResponseClient) {
+		hc := *g.httpClient
+		hc.Transport = rt
+		g.httpClient = &hc
+	}
+}
+
+// WithBaseURL sends requests to baseURL instead of https://api.getresponse.com/
+func WithBaseURL(baseURL string) Option {
+	return func(g *getResponseClient) {
+		g.finder = staticFinder(baseURL)
+	}
+}
+
+// WithMAX talks to a GetResponse MAX account, sending domain as the X-Domain header of every request
+func WithMAX(domain string, region MAXRegion) Option {
+	return func(g *getResponseClient) {
+		g.finder = staticFinder(string(region))
+		g.domain = domain
+	}
+}
+
+// WithUserAgent overrides the User-Agent header
+func WithUserAgent(userAgent string) Option {
+	return func(g *getResponseClient) {
+		g.userAgent = userAgent
+	}
+}
+
+// WithRetryPolicy makes the client retry throttled and failed requests according to p
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(g *getResponseClient) {
+		g.retry = p
+	}
+}
+
+// WithPollPolicy sets how long the client waits for asynchronous operations such as contact creation
+func WithPollPolicy(p PollPolicy) Option {
+	return func(g *getResponseClient) {
+		g.pollPolicy = p
+	}
+}
+
+// WithImportChunkSize sets how many contacts ImportContacts puts in each import, at most MaxImportContacts
+func WithImportChunkSize(n int) Option {
+	return func(g *getResponseClient) {
+		if n > 0 && n <= MaxImportContacts {
+			g.importSize = n
+		}
+	}
+}
+
+// WithCustomFieldValidation validates every custom field value against the account's custom field definitions
+// before sending it, not only the typed ones
+func WithCustomFieldValidation() Option {
+	return func(g *getResponseClient) {
+		g.validate = true
+	}
+}
+
+// WithRateLimiter throttles the client's requests with l.  Pass the same limiter to every client using the
+// same GR account so they share its quota.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(g *getResponseClient) {
+		g.limiter = l
+	}
+}
+
+// WithLogger logs every request and retry to l
+func WithLogger(l Logger) Option {
+	return func(g *getResponseClient) {
+		g.logger = l
+	}
+}
+
+// WithHooks calls h around every round trip
+func WithHooks(h Hooks) Option {
+	return func(g *getResponseClient) {
+		g.hooks = h
+	}
+}
@@ -0,0 +1,130 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+type countingTransport struct {
+	calls int
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestUnit_NewClientWithOptions(t *testing.T) {
+	var gotPath, gotUserAgent, gotAuth, gotHook string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUserAgent = r.Header.Get("User-Agent")
+		gotAuth = r.Header.Get("X-Auth-Token")
+		gotHook = r.Header.Get("X-Hook")
+		fmt.Fprint(w, `{"name": "foobar"}`)
+	}))
+	defer ts.Close()
+
+	logger := &testLogger{}
+	transport := &countingTransport{}
+	shared := &http.Client{Transport: transport}
+	afterStatus := 0
+	c := NewClientWithOptions(
+		WithAPIKey("secret"),
+		WithBaseURL(ts.URL+"/proxy/"),
+		WithHTTPClient(shared),
+		WithTimeout(5*time.Second),
+		WithUserAgent("onboarding/1.0"),
+		WithLogger(logger),
+		WithHooks(Hooks{
+			BeforeRequest: func(req *http.Request) { req.Header.Set("X-Hook", "before") },
+			AfterResponse: func(req *http.Request, resp *http.Response, err error) { afterStatus = resp.StatusCode },
+		}),
+	)
+
+	if _, err := c.GetContact(context.Background(), "foo", nil); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if gotPath != "/proxy/v3/contacts/foo" {
+		t.Fatalf("Actual path (%s) did not keep the base URL prefix", gotPath)
+	}
+	if gotUserAgent != "onboarding/1.0" || gotAuth != "api-key secret" || gotHook != "before" {
+		t.Fatalf("Unexpected headers: user agent (%s) auth (%s) hook (%s)", gotUserAgent, gotAuth, gotHook)
+	}
+	if afterStatus != http.StatusOK {
+		t.Fatalf("AfterResponse hook saw status %d", afterStatus)
+	}
+	if transport.calls != 1 {
+		t.Fatalf("Custom transport was used %d times", transport.calls)
+	}
+	if shared.Timeout != 0 {
+		t.Fatalf("WithTimeout modified the caller's http client")
+	}
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "GET /v3/contacts/foo returned 200") {
+		t.Fatalf("Unexpected log lines (%#v)", logger.lines)
+	}
+}
+
+func TestUnit_NewClientDefaults(t *testing.T) {
+	g := NewClient("secret", time.Second).(*getResponseClient)
+	if g.apiKey != "secret" || g.httpClient.Timeout != time.Second || g.userAgent != DefaultUserAgent {
+		t.Fatalf("Unexpected client configuration (%#v)", g)
+	}
+	u, err := g.finder("getresponse", true)
+	if err != nil || u.String() != "https://api.getresponse.com/" {
+		t.Fatalf("Actual base URL (%s) did not match expected", u.String())
+	}
+}
+
+func TestUnit_WithMAX(t *testing.T) {
+	type testcase struct {
+		name           string
+		region         MAXRegion
+		expectedURL    string
+		expectedDomain string
+	}
+
+	testcases := []testcase{
+		testcase{name: "us", region: MAXRegionUS, expectedURL: "https://api3.getresponse360.com/", expectedDomain: "example.com"},
+		testcase{name: "pl", region: MAXRegionPL, expectedURL: "https://api3.getresponse360.pl/", expectedDomain: "example.com"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewClientWithOptions(WithMAX(tc.expectedDomain, tc.region)).(*getResponseClient)
+			u, err := g.finder("getresponse", true)
+			if err != nil || u.String() != tc.expectedURL {
+				t.Fatalf("Actual base URL (%s) did not match expected (%s)", u.String(), tc.expectedURL)
+			}
+			if g.domain != tc.expectedDomain {
+				t.Fatalf("Actual domain (%s) did not match expected (%s)", g.domain, tc.expectedDomain)
+			}
+		})
+	}
+
+	var gotDomain string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDomain = r.Header.Get("X-Domain")
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithMAX("example.com", MAXRegionUS), WithBaseURL(ts.URL))
+	if err := c.DeleteContact(context.Background(), "foo", "", ""); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if gotDomain != "example.com" {
+		t.Fatalf("Actual X-Domain header (%s) did not match expected (example.com)", gotDomain)
+	}
+}
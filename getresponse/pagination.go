@@ -0,0 +1,106 @@
+package getresponse
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// DefaultPerPage is the page size iterators use when none is given
+const DefaultPerPage = 100
+
+// Pagination holds the paging headers GR sends with every collection response
+type Pagination struct {
+	TotalCount  int
+	TotalPages  int
+	CurrentPage int
+}
+
+func paginationFromHeader(h http.Header) Pagination {
+	ret := Pagination{}
+	ret.TotalCount, _ = strconv.Atoi(h.Get("TotalCount"))
+	ret.TotalPages, _ = strconv.Atoi(h.Get("TotalPages"))
+	ret.CurrentPage, _ = strconv.Atoi(h.Get("CurrentPage"))
+	return ret
+}
+
+// pageFetcher fetches one page of a collection into the caller's buffer and returns how many items it read
+type pageFetcher func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError)
+
+// pager holds the paging state behind every typed iterator; the iterators only own their item buffer
+type pager struct {
+	ctx        context.Context
+	fetch      pageFetcher
+	perPage    int32
+	page       int32
+	done       bool
+	err        glitch.DataError
+	pagination Pagination
+}
+
+func newPager(ctx context.Context, perPage int32, fetch pageFetcher) *pager {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	return &pager{ctx: ctx, fetch: fetch, perPage: perPage, page: 1}
+}
+
+// alive reports whether the iteration may continue, recording the context error when it is done
+func (p *pager) alive() bool {
+	if p.err != nil {
+		return false
+	}
+	if err := p.ctx.Err(); err != nil {
+		p.err = glitch.NewDataError(err, ErrorCanceled, "Context done while iterating")
+		return false
+	}
+	return true
+}
+
+// fetchNext fetches the next page and reports whether it contained any items
+func (p *pager) fetchNext() bool {
+	if p.done || !p.alive() {
+		return false
+	}
+
+	n, h, err := p.fetch(p.ctx, p.page, p.perPage)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.pagination = paginationFromHeader(h)
+	switch {
+	case n == 0:
+		p.done = true
+	case p.pagination.TotalPages > 0:
+		p.done = p.pagination.CurrentPage >= p.pagination.TotalPages
+	default:
+		// no paging headers, a short page is the last one
+		p.done = int32(n) < p.perPage
+	}
+	p.page++
+	return n > 0
+}
+
+// iterator is the part of the typed iterators findExact needs
+type iterator interface {
+	Next() bool
+	Err() glitch.DataError
+}
+
+// findExact advances it to the first item matches accepts and reports whether there was one.  GR's query[...]
+// parameters also match partial values, e.g. query[name]=vip finds "vip_2019", so lookups check every result.
+func findExact(it iterator, matches func() bool) (bool, glitch.DataError) {
+	for it.Next() {
+		if matches() {
+			return true, nil
+		}
+	}
+	return false, it.Err()
+}
@@ -0,0 +1,236 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// pagedContactsHandler serves the given pages of contacts, optionally with the GR paging headers
+func pagedContactsHandler(pages [][]string, withHeaders bool, requested *[]string) http.HandlerFunc {
+	return pagedHandler("/v3/contacts", "email", pages, withHeaders, requested)
+}
+
+// pagedHandler serves the given pages of resources at path, each page item being an object with field set to the
+// item's value
+func pagedHandler(path string, field string, pages [][]string, withHeaders bool, requested *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if requested != nil {
+			*requested = append(*requested, r.URL.Query().Get("page"))
+		}
+		if withHeaders {
+			w.Header().Set("TotalPages", strconv.Itoa(len(pages)))
+			w.Header().Set("CurrentPage", strconv.Itoa(page))
+			w.Header().Set("TotalCount", "5")
+		}
+		if page < 1 || page > len(pages) {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, "[")
+		for i, value := range pages[page-1] {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"%s": "%s"}`, field, value)
+		}
+		fmt.Fprint(w, "]")
+	}
+}
+
+func TestUnit_IterateContacts(t *testing.T) {
+
+	type testcase struct {
+		name              string
+		handler           http.HandlerFunc
+		perPage           int32
+		expectedEmails    []string
+		expectedRequested []string
+		expectedErrCode   *string
+	}
+
+	requested := []string{}
+	pages := [][]string{{"a@b.c", "b@b.c"}, {"c@b.c", "d@b.c"}, {"e@b.c"}}
+
+	testcases := []testcase{
+		testcase{
+			name:              "stops on last page from headers",
+			handler:           pagedContactsHandler(pages, true, &requested),
+			perPage:           2,
+			expectedEmails:    []string{"a@b.c", "b@b.c", "c@b.c", "d@b.c", "e@b.c"},
+			expectedRequested: []string{"1", "2", "3"},
+		},
+		testcase{
+			name:              "stops on short page without headers",
+			handler:           pagedContactsHandler(pages, false, &requested),
+			perPage:           2,
+			expectedEmails:    []string{"a@b.c", "b@b.c", "c@b.c", "d@b.c", "e@b.c"},
+			expectedRequested: []string{"1", "2", "3"},
+		},
+		testcase{
+			name:              "stops on empty page without headers",
+			handler:           pagedContactsHandler([][]string{{"a@b.c", "b@b.c"}}, false, &requested),
+			perPage:           2,
+			expectedEmails:    []string{"a@b.c", "b@b.c"},
+			expectedRequested: []string{"1", "2"},
+		},
+		testcase{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"code":1014}`)
+			}),
+			perPage:         2,
+			expectedEmails:  []string{},
+			expectedErrCode: makeStringPtr("1014"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			requested = []string{}
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			it := c.IterateContacts(context.Background(), nil, nil, nil, tc.perPage, nil)
+			emails := []string{}
+			for it.Next() {
+				emails = append(emails, *it.Contact().Email)
+			}
+			if !reflect.DeepEqual(tc.expectedEmails, emails) {
+				t.Fatalf("Actual emails (%#v) did not match expected (%#v)", emails, tc.expectedEmails)
+			}
+			if tc.expectedRequested != nil && !reflect.DeepEqual(tc.expectedRequested, requested) {
+				t.Fatalf("Actual pages requested (%#v) did not match expected (%#v)", requested, tc.expectedRequested)
+			}
+			err := it.Err()
+			if tc.expectedErrCode != nil || err != nil {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUnit_IterateCampaigns(t *testing.T) {
+
+	type testcase struct {
+		name              string
+		handler           http.HandlerFunc
+		perPage           int32
+		expectedIDs       []string
+		expectedRequested []string
+		expectedErrCode   *string
+	}
+
+	requested := []string{}
+	pages := [][]string{{"V", "W"}, {"X"}}
+
+	testcases := []testcase{
+		testcase{
+			name:              "stops on last page from headers",
+			handler:           pagedHandler("/v3/campaigns", "campaignId", pages, true, &requested),
+			perPage:           2,
+			expectedIDs:       []string{"V", "W", "X"},
+			expectedRequested: []string{"1", "2"},
+		},
+		testcase{
+			name:              "stops on short page without headers",
+			handler:           pagedHandler("/v3/campaigns", "campaignId", pages, false, &requested),
+			perPage:           2,
+			expectedIDs:       []string{"V", "W", "X"},
+			expectedRequested: []string{"1", "2"},
+		},
+		testcase{
+			name:              "stops on empty page without headers",
+			handler:           pagedHandler("/v3/campaigns", "campaignId", [][]string{{"V", "W"}}, false, &requested),
+			perPage:           2,
+			expectedIDs:       []string{"V", "W"},
+			expectedRequested: []string{"1", "2"},
+		},
+		testcase{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"code":1014}`)
+			}),
+			perPage:         2,
+			expectedIDs:     []string{},
+			expectedErrCode: makeStringPtr("1014"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			requested = []string{}
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			it := c.IterateCampaigns(context.Background(), nil, nil, nil, tc.perPage)
+			ids := []string{}
+			for it.Next() {
+				ids = append(ids, it.Campaign().CampaignID)
+			}
+			if !reflect.DeepEqual(tc.expectedIDs, ids) {
+				t.Fatalf("Actual campaign ids (%#v) did not match expected (%#v)", ids, tc.expectedIDs)
+			}
+			if tc.expectedRequested != nil && !reflect.DeepEqual(tc.expectedRequested, requested) {
+				t.Fatalf("Actual pages requested (%#v) did not match expected (%#v)", requested, tc.expectedRequested)
+			}
+			checkDataError(t, it.Err(), tc.expectedErrCode)
+		})
+	}
+}
+
+func TestUnit_IterateContactsCanceled(t *testing.T) {
+	c, ts := testClient(pagedContactsHandler([][]string{{"a@b.c", "b@b.c"}, {"c@b.c"}}, true, nil), 5*time.Second)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.IterateContacts(ctx, nil, nil, nil, 2, nil)
+	if !it.Next() {
+		t.Fatalf("Expected a first contact, got error (%#v)", it.Err())
+	}
+	cancel()
+	if it.Next() {
+		t.Fatalf("Iterator kept going after cancellation")
+	}
+	if it.Err() == nil || it.Err().Code() != ErrorCanceled {
+		t.Fatalf("Actual error (%#v) did not match expected (%#v)", it.Err(), ErrorCanceled)
+	}
+}
+
+func TestUnit_ContactIteratorChan(t *testing.T) {
+	c, ts := testClient(pagedContactsHandler([][]string{{"a@b.c", "b@b.c"}, {"c@b.c"}}, true, nil), 5*time.Second)
+	defer ts.Close()
+
+	it := c.IterateContacts(context.Background(), nil, nil, nil, 2, nil)
+	emails := []string{}
+	for contact := range it.Chan() {
+		emails = append(emails, *contact.Email)
+	}
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", it.Err())
+	}
+	expected := []string{"a@b.c", "b@b.c", "c@b.c"}
+	if !reflect.DeepEqual(expected, emails) {
+		t.Fatalf("Actual emails (%#v) did not match expected (%#v)", emails, expected)
+	}
+	if it.Pagination().TotalPages != 2 {
+		t.Fatalf("Actual pagination (%#v) did not report 2 pages", it.Pagination())
+	}
+}
@@ -0,0 +1,136 @@
+package getresponse
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// PollPolicy controls how the client waits for asynchronous GR operations to finish
+type PollPolicy struct {
+	Interval    time.Duration // wait between the first checks, doubled after each one; DefaultPollPolicy's if 0
+	MaxInterval time.Duration // cap on the wait between checks
+	Timeout     time.Duration // give up after this long, 0 means only the context deadline limits it
+}
+
+// DefaultPollPolicy is used by the client unless WithPollPolicy overrides it
+var DefaultPollPolicy = PollPolicy{
+	Interval:    500 * time.Millisecond,
+	MaxInterval: 10 * time.Second,
+	Timeout:     2 * time.Minute,
+}
+
+// poll calls check straight away and then with backoff until it reports done, fails or time runs out
+func poll(ctx context.Context, p PollPolicy, check func(ctx context.Context) (bool, glitch.DataError)) glitch.DataError {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	wait := p.Interval
+	if wait <= 0 {
+		// checking back to back would burn through the account's quota
+		wait = DefaultPollPolicy.Interval
+	}
+	for {
+		done, err := check(ctx)
+		if err != nil && ctx.Err() != nil {
+			// the check was cut short by the timeout or a cancellation
+			return pollStopped(ctx.Err())
+		}
+		if err != nil || done {
+			return err
+		}
+
+		if !sleepContext(ctx, wait) {
+			err := ctx.Err()
+			if err == nil {
+				err = context.DeadlineExceeded
+			}
+			return pollStopped(err)
+		}
+		wait *= 2
+		if p.MaxInterval > 0 && wait > p.MaxInterval {
+			wait = p.MaxInterval
+		}
+	}
+}
+
+// pollStopped tells a caller's cancellation apart from running out of time
+func pollStopped(err error) glitch.DataError {
+	if err == context.Canceled {
+		return glitch.NewDataError(err, ErrorCanceled, "Stopped waiting for GR to finish the operation")
+	}
+	return glitch.NewDataError(err, ErrorTimeout, "Gave up waiting for GR to finish the operation")
+}
+
+// PendingContact is a contact GR accepted for creation but may not have created yet
+type PendingContact struct {
+	Email      string
+	CampaignID string
+	g          *getResponseClient
+}
+
+// Poll checks once whether the contact has been created, returning nil if it hasn't
+func (p *PendingContact) Poll(ctx context.Context) (*Contact, glitch.DataError) {
+	return p.g.findContact(ctx, p.Email, p.CampaignID)
+}
+
+// Wait polls until the contact has been created and returns it
+func (p *PendingContact) Wait(ctx context.Context, policy PollPolicy) (Contact, glitch.DataError) {
+	result := Contact{}
+	err := poll(ctx, policy, func(ctx context.Context) (bool, glitch.DataError) {
+		c, err := p.Poll(ctx)
+		if err != nil || c == nil {
+			return false, err
+		}
+		result = *c
+		return true, nil
+	})
+	return result, err
+}
+
+func (g *getResponseClient) CreateContactAsync(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, ipAddress *string) (*PendingContact, glitch.DataError) {
+	err := g.CreateContact(ctx, email, name, dayOfCycle, campaignID, customFields, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingContact{Email: email, CampaignID: campaignID, g: g}, nil
+}
+
+func (g *getResponseClient) CreateContactAndWait(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, ipAddress *string) (Contact, glitch.DataError) {
+	pending, err := g.CreateContactAsync(ctx, email, name, dayOfCycle, campaignID, customFields, ipAddress)
+	if err != nil {
+		return Contact{}, err
+	}
+	return pending.Wait(ctx, g.pollPolicy)
+}
+
+// createContactWithTags creates the contact with its tags and returns a handle to wait for it; CreateContact uses it too
+func (g *getResponseClient) createContactWithTags(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, tags []Tag, ipAddress *string) (*PendingContact, glitch.DataError) {
+	customFields, err := g.encodeCustomFields(ctx, customFields)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyObj := createContactRequest{
+		Email:             email,
+		Name:              name,
+		DayOfCycle:        dayOfCycle,
+		Campaign:          Campaign{CampaignID: campaignID},
+		CustomFieldValues: customFields,
+		Tags:              tags,
+		IPAddress:         ipAddress,
+	}
+	err = g.do(ctx, http.MethodPost, "/v3/contacts", nil, bodyObj, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingContact{Email: email, CampaignID: campaignID, g: g}, nil
+}
@@ -0,0 +1,168 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+func TestUnit_CreateContactAndWait(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		createStatus     int
+		visibleAfter     int
+		expectedErrCode  *string
+		expectedResponse Contact
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:             "base path",
+			createStatus:     http.StatusAccepted,
+			visibleAfter:     2,
+			expectedResponse: Contact{ContactID: makeStringPtr("abc"), Email: makeStringPtr("foo@bar.baz")},
+		},
+		testcase{
+			name:            "never created",
+			createStatus:    http.StatusAccepted,
+			visibleAfter:    1000,
+			expectedErrCode: makeStringPtr(ErrorTimeout),
+		},
+		testcase{
+			name:            "error response",
+			createStatus:    http.StatusConflict,
+			expectedErrCode: makeStringPtr("1008"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			lists := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.WriteHeader(tc.createStatus)
+					if tc.createStatus >= 400 {
+						fmt.Fprint(w, `{"code":1008}`)
+					}
+					return
+				}
+				if r.URL.Query().Get("query[email]") != "foo@bar.baz" || r.URL.Query().Get("query[campaignId]") != "V" {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"code":1000}`)
+					return
+				}
+				lists++
+				if lists <= tc.visibleAfter {
+					fmt.Fprint(w, `[]`)
+					return
+				}
+				fmt.Fprint(w, `[{"contactId": "abc", "email": "foo@bar.baz"}]`)
+			}))
+			defer ts.Close()
+			c := NewClientWithOptions(WithBaseURL(ts.URL), WithPollPolicy(testPollPolicy))
+
+			ret, err := c.CreateContactAndWait(context.Background(), "foo@bar.baz", nil, nil, "V", nil, nil)
+			if err == nil && tc.expectedErrCode == nil {
+				if !reflect.DeepEqual(tc.expectedResponse, ret) {
+					t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+				}
+				if lists != tc.visibleAfter+1 {
+					t.Fatalf("Actual polls (%d) did not match expected (%d)", lists, tc.visibleAfter+1)
+				}
+			} else {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUnit_PendingContactPoll(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	pending, err := c.CreateContactAsync(context.Background(), "foo@bar.baz", nil, nil, "V", nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if pending.Email != "foo@bar.baz" || pending.CampaignID != "V" {
+		t.Fatalf("Unexpected pending contact (%#v)", pending)
+	}
+	contact, err := pending.Poll(context.Background())
+	if err != nil || contact != nil {
+		t.Fatalf("Expected the contact not to exist yet, got (%#v, %#v)", contact, err)
+	}
+}
+
+func TestUnit_PollStopped(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		policy          PollPolicy
+		cancel          bool
+		maxChecks       int
+		expectedErrCode string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:            "timeout",
+			policy:          PollPolicy{Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+			expectedErrCode: ErrorTimeout,
+		},
+		testcase{
+			name:            "zero interval",
+			policy:          PollPolicy{Timeout: 200 * time.Millisecond},
+			maxChecks:       1,
+			expectedErrCode: ErrorTimeout,
+		},
+		testcase{
+			name:            "canceled",
+			policy:          PollPolicy{Interval: time.Millisecond},
+			cancel:          true,
+			expectedErrCode: ErrorCanceled,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			checks := 0
+			err := poll(ctx, tc.policy, func(ctx context.Context) (bool, glitch.DataError) {
+				checks++
+				if tc.cancel && checks == 3 {
+					cancel()
+				}
+				return false, nil
+			})
+			if err == nil || err.Code() != tc.expectedErrCode {
+				t.Fatalf("Actual error (%#v) did not match expected (%#v)", err, tc.expectedErrCode)
+			}
+			if tc.maxChecks > 0 && checks > tc.maxChecks {
+				t.Fatalf("Expected at most %d checks, got %d", tc.maxChecks, checks)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package getresponse
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// GR plan quotas - https://apidocs.getresponse.com/v3/limits
+const (
+	DefaultRequestsPerSecond     = 80
+	DefaultRequestsPerTenMinutes = 30000
+)
+
+// Budget is the number of requests a RateLimiter would let through right now
+type Budget struct {
+	PerSecond     int
+	PerTenMinutes int
+}
+
+// RateLimiter is a client side throttle made of a per second and a per 10 minute token bucket.  It is safe
+// to share between goroutines and between clients using the same account.  Responses keep it in step with
+// the X-RateLimit-* headers GR sends, so calls made elsewhere on the account are accounted for too.
+type RateLimiter struct {
+	mu           sync.Mutex
+	second       bucket
+	window       bucket
+	blockedUntil time.Time
+}
+
+type bucket struct {
+	capacity float64
+	tokens   float64
+	rate     float64 // tokens per second, 0 for a bucket that never runs out
+	last     time.Time
+}
+
+func newBucket(capacity int, per time.Duration, now time.Time) bucket {
+	if capacity <= 0 {
+		return bucket{}
+	}
+	return bucket{capacity: float64(capacity), tokens: float64(capacity), rate: float64(capacity) / per.Seconds(), last: now}
+}
+
+func (b *bucket) unlimited() bool {
+	return b.rate <= 0
+}
+
+func (b *bucket) refill(now time.Time) {
+	if !b.unlimited() && now.After(b.last) {
+		b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// wait returns how long until a token is available
+func (b *bucket) wait() time.Duration {
+	if b.unlimited() || b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *bucket) take() {
+	if !b.unlimited() {
+		b.tokens--
+	}
+}
+
+func (b *bucket) budget() int {
+	if b.unlimited() {
+		return math.MaxInt32
+	}
+	return int(b.tokens)
+}
+
+// NewRateLimiter returns a limiter allowing perSecond requests a second and perTenMinutes requests every
+// 10 minutes.  Both buckets start full; a limit of 0 or less leaves that bucket unlimited.
+func NewRateLimiter(perSecond int, perTenMinutes int) *RateLimiter {
+	now := time.Now()
+	return &RateLimiter{
+		second: newBucket(perSecond, time.Second, now),
+		window: newBucket(perTenMinutes, 10*time.Minute, now),
+	}
+}
+
+// Wait blocks until a request may be made, failing straight away if that would be after the context deadline
+func (l *RateLimiter) Wait(ctx context.Context) glitch.DataError {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for {
+		l.mu.Lock()
+		wait := l.reserve(time.Now())
+		l.mu.Unlock()
+		if wait == 0 {
+			return nil
+		}
+		if !sleepContext(ctx, wait) {
+			err := ctx.Err()
+			if err == nil {
+				err = context.DeadlineExceeded
+			}
+			return glitch.NewDataError(err, ErrorCanceled, "Context done while waiting for the rate limiter")
+		}
+	}
+}
+
+// reserve takes a token from both buckets if it can, otherwise it returns how long to wait before trying again
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	l.second.refill(now)
+	l.window.refill(now)
+
+	wait := l.second.wait()
+	if w := l.window.wait(); w > wait {
+		wait = w
+	}
+	if wait > 0 {
+		return wait
+	}
+	l.second.take()
+	l.window.take()
+	return 0
+}
+
+// Budget returns how many requests could be made right now without waiting, math.MaxInt32 for an unlimited bucket
+func (l *RateLimiter) Budget() Budget {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.blockedUntil) {
+		return Budget{}
+	}
+	l.second.refill(now)
+	l.window.refill(now)
+	return Budget{PerSecond: l.second.budget(), PerTenMinutes: l.window.budget()}
+}
+
+// observe adjusts the 10 minute bucket to what GR reports is left of the account's quota
+func (l *RateLimiter) observe(rl RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.window.refill(now)
+	if !l.window.unlimited() && float64(rl.Remaining) < l.window.tokens {
+		l.window.tokens = float64(rl.Remaining)
+	}
+	if rl.Remaining <= 0 && rl.Reset > 0 {
+		l.blockedUntil = now.Add(rl.Reset)
+	}
+}
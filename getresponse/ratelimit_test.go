@@ -0,0 +1,137 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUnit_RateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(10, 1000)
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("Burst of 10 took %s, expected no waiting", elapsed)
+	}
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("11th request after %s, expected it to wait for a token", elapsed)
+	}
+
+	budget := l.Budget()
+	if budget.PerSecond != 0 || budget.PerTenMinutes != 989 {
+		t.Fatalf("Actual budget (%#v) did not match expected", budget)
+	}
+}
+
+func TestUnit_RateLimiterDeadline(t *testing.T) {
+	l := NewRateLimiter(1, 1000)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := l.Wait(ctx)
+	if err == nil || err.Code() != ErrorCanceled {
+		t.Fatalf("Actual error (%#v) did not match expected (%#v)", err, ErrorCanceled)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("Wait took %s, expected it to give up straight away", elapsed)
+	}
+}
+
+func TestUnit_RateLimiterUnlimited(t *testing.T) {
+	l := NewRateLimiter(0, -1)
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("100 unlimited requests took %s, expected no waiting", elapsed)
+	}
+	if budget := l.Budget(); budget.PerSecond != math.MaxInt32 || budget.PerTenMinutes != math.MaxInt32 {
+		t.Fatalf("Actual budget (%#v) did not match expected", budget)
+	}
+
+	l = NewRateLimiter(2, 0)
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("3rd request after %s, expected the per second bucket to still throttle", elapsed)
+	}
+	if budget := l.Budget(); budget.PerSecond != 0 || budget.PerTenMinutes != math.MaxInt32 {
+		t.Fatalf("Actual budget (%#v) did not match expected", budget)
+	}
+}
+
+func TestUnit_RateLimiterShared(t *testing.T) {
+	l := NewRateLimiter(1000, 50)
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	allowed := 0
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if l.Wait(ctx) == nil {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed != 50 {
+		t.Fatalf("Actual requests allowed (%d) did not match the 10 minute budget (50)", allowed)
+	}
+}
+
+func TestUnit_RateLimiterObservesHeaders(t *testing.T) {
+	l := NewRateLimiter(DefaultRequestsPerSecond, DefaultRequestsPerTenMinutes)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "60 seconds")
+		fmt.Fprint(w, `{"name": "foobar"}`)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL), WithRateLimiter(l))
+
+	if _, err := c.GetContact(context.Background(), "foo", nil); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if budget := l.Budget(); budget != (Budget{}) {
+		t.Fatalf("Actual budget (%#v) should be empty once GR reports the quota is used up", budget)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := c.GetContact(ctx, "foo", nil)
+	if err == nil || err.Code() != ErrorCanceled {
+		t.Fatalf("Actual error (%#v) did not match expected (%#v)", err, ErrorCanceled)
+	}
+}
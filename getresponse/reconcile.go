@@ -0,0 +1,481 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/healthimation/go-glitch/glitch"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec describes resources an account should have, e.g. loaded with ParseSpec from a file kept with the code.
+// Resources are matched by name so the same spec applies to any account; resources the spec doesn't mention are left
+// alone and fields it leaves unset are not compared.
+type Spec struct {
+	FromFields     []FromField             `json:"fromFields,omitempty"` // matched by email, only isDefault is updated
+	Campaigns      []Campaign              `json:"campaigns,omitempty"`
+	CustomFields   []CustomFieldDefinition `json:"customFields,omitempty"`
+	Tags           []Tag                   `json:"tags,omitempty"`
+	Autoresponders []AutoresponderSpec     `json:"autoresponders,omitempty"`
+}
+
+// AutoresponderSpec is an autoresponder of a Spec, which references its campaign by name rather than by ID
+type AutoresponderSpec struct {
+	Autoresponder
+	Campaign string `json:"campaign"` // name of the campaign, required
+}
+
+// Reconcile change actions
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+)
+
+// Resources of reconcile changes
+const (
+	ResourceFromField     = "from field"
+	ResourceCampaign      = "campaign"
+	ResourceCustomField   = "custom field"
+	ResourceTag           = "tag"
+	ResourceAutoresponder = "autoresponder"
+)
+
+// Change is a create or update needed to make an account match a Spec
+type Change struct {
+	Action   string           // ChangeCreate or ChangeUpdate
+	Resource string           // Resource* constants
+	Name     string           // name, or email of a from field; autoresponders are prefixed by their campaign's name
+	ID       string           // ID of the updated resource
+	Fields   []string         // the fields an update changes, nested fields are dotted
+	Err      glitch.DataError // set by Reconcile when the change could not be applied
+
+	apply func(ctx context.Context) glitch.DataError
+}
+
+// String describes the change on one line, like a line of a diff
+func (c Change) String() string {
+	if c.Action == ChangeCreate {
+		return fmt.Sprintf("+ %s %q", c.Resource, c.Name)
+	}
+	return fmt.Sprintf("~ %s %q (%s): %s", c.Resource, c.Name, c.ID, strings.Join(c.Fields, ", "))
+}
+
+// Plan lists the changes needed to make an account match a Spec, in the order they are applied
+type Plan struct {
+	Changes []Change
+}
+
+// String describes the plan a change per line, or says there is nothing to do
+func (p Plan) String() string {
+	if len(p.Changes) == 0 {
+		return "no changes\n"
+	}
+	b := strings.Builder{}
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		if c.Err != nil {
+			b.WriteString(" FAILED: " + c.Err.Error())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ParseSpec reads a YAML or JSON spec, rejecting unknown fields so typos don't go unnoticed.  YAML is converted to
+// JSON first so both use the json field names; GR's "true"/"false" fields must be quoted in YAML.
+func ParseSpec(r io.Reader) (Spec, glitch.DataError) {
+	spec := Spec{}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return spec, newValidationError("Could not read the spec", err.Error())
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		if b, err = yamlToJSON(b); err != nil {
+			return spec, newValidationError("Invalid spec", err.Error())
+		}
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&spec); err != nil {
+		return spec, newValidationError("Invalid spec", err.Error())
+	}
+	return spec, nil
+}
+
+// yamlToJSON converts a YAML document to JSON
+func yamlToJSON(b []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonValue(doc))
+}
+
+// jsonValue replaces the map[interface{}]interface{} YAML decodes mappings into, which encoding/json can't marshal
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = jsonValue(e)
+		}
+	}
+	return v
+}
+
+// PlanSpec compares the account with spec and returns the changes Reconcile would make, without making them
+func PlanSpec(ctx context.Context, c Client, spec Spec) (Plan, glitch.DataError) {
+	if err := spec.validate(); err != nil {
+		return Plan{}, err
+	}
+	st, err := loadReconcileState(ctx, c, spec)
+	if err != nil {
+		return Plan{}, err
+	}
+	return st.plan(c, spec)
+}
+
+// Reconcile makes the account match spec and returns the plan it applied.  A change which fails doesn't stop the
+// others, except for the autoresponders of a campaign that could not be created; the error returned is that of the
+// first failed change.
+func Reconcile(ctx context.Context, c Client, spec Spec) (Plan, glitch.DataError) {
+	plan, err := PlanSpec(ctx, c, spec)
+	if err != nil {
+		return plan, err
+	}
+	var first glitch.DataError
+	for i := range plan.Changes {
+		plan.Changes[i].Err = plan.Changes[i].apply(ctx)
+		if first == nil {
+			first = plan.Changes[i].Err
+		}
+	}
+	return plan, first
+}
+
+func (s Spec) validate() glitch.DataError {
+	problems := []string{}
+	check := func(resource string, names []string) {
+		seen := map[string]bool{}
+		for _, name := range names {
+			if name == "" {
+				problems = append(problems, fmt.Sprintf("a %s has no name", resource))
+			} else if seen[name] {
+				problems = append(problems, fmt.Sprintf("%s %q is listed twice", resource, name))
+			}
+			seen[name] = true
+		}
+	}
+
+	names := []string{}
+	for _, f := range s.FromFields {
+		names = append(names, derefString(f.Email))
+	}
+	check(ResourceFromField, names)
+	names = names[:0]
+	for _, c := range s.Campaigns {
+		names = append(names, c.Name)
+	}
+	check(ResourceCampaign, names)
+	names = names[:0]
+	for _, f := range s.CustomFields {
+		names = append(names, f.Name)
+	}
+	check(ResourceCustomField, names)
+	names = names[:0]
+	for _, t := range s.Tags {
+		names = append(names, derefString(t.Name))
+	}
+	check(ResourceTag, names)
+	// autoresponders are matched by campaign and name
+	seen := map[string]bool{}
+	for _, a := range s.Autoresponders {
+		name := derefString(a.Name)
+		if a.Campaign == "" {
+			problems = append(problems, fmt.Sprintf("autoresponder %q has no campaign", name))
+		}
+		if name == "" {
+			problems = append(problems, fmt.Sprintf("an autoresponder of campaign %q has no name", a.Campaign))
+		} else if seen[a.Campaign+"/"+name] {
+			problems = append(problems, fmt.Sprintf("autoresponder %q of campaign %q is listed twice", name, a.Campaign))
+		}
+		seen[a.Campaign+"/"+name] = true
+		for _, p := range a.TriggerSettings.problems() {
+			problems = append(problems, fmt.Sprintf("autoresponder %q: %s", derefString(a.Name), p))
+		}
+	}
+
+	if len(problems) > 0 {
+		return newValidationError("Invalid spec", problems...)
+	}
+	return nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// reconcileState is what the account has of the resources a spec mentions, keyed by name
+type reconcileState struct {
+	fromFields     map[string]FromField // keyed by email
+	campaigns      map[string]Campaign
+	customFields   map[string]CustomFieldDefinition
+	tags           map[string]Tag
+	autoresponders map[string]Autoresponder // keyed by campaign ID and name
+
+	campaignIDs map[string]string // campaign IDs by name, including the campaigns Reconcile creates
+}
+
+func loadReconcileState(ctx context.Context, c Client, spec Spec) (*reconcileState, glitch.DataError) {
+	st := &reconcileState{
+		fromFields:     map[string]FromField{},
+		campaigns:      map[string]Campaign{},
+		customFields:   map[string]CustomFieldDefinition{},
+		tags:           map[string]Tag{},
+		autoresponders: map[string]Autoresponder{},
+		campaignIDs:    map[string]string{},
+	}
+
+	if len(spec.FromFields) > 0 {
+		it := c.IterateFromFields(ctx, nil, nil, nil, DefaultPerPage)
+		for it.Next() {
+			st.fromFields[derefString(it.FromField().Email)] = it.FromField()
+		}
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+	}
+	if len(spec.Campaigns) > 0 || len(spec.Autoresponders) > 0 {
+		it := c.IterateCampaigns(ctx, nil, nil, nil, DefaultPerPage)
+		for it.Next() {
+			st.campaigns[it.Campaign().Name] = it.Campaign()
+			st.campaignIDs[it.Campaign().Name] = it.Campaign().CampaignID
+		}
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+	}
+	if len(spec.CustomFields) > 0 {
+		it := c.IterateCustomFields(ctx, nil, nil, nil, DefaultPerPage)
+		for it.Next() {
+			st.customFields[it.CustomField().Name] = it.CustomField()
+		}
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+	}
+	if len(spec.Tags) > 0 {
+		it := c.IterateTags(ctx, nil, nil, nil, DefaultPerPage)
+		for it.Next() {
+			st.tags[derefString(it.Tag().Name)] = it.Tag()
+		}
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+	}
+	if len(spec.Autoresponders) > 0 {
+		it := c.IterateAutoresponders(ctx, nil, nil, nil, DefaultPerPage)
+		for it.Next() {
+			a := it.Autoresponder()
+			st.autoresponders[derefString(a.CampaignID)+"/"+derefString(a.Name)] = a
+		}
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+	}
+	return st, nil
+}
+
+func (st *reconcileState) plan(c Client, spec Spec) (Plan, glitch.DataError) {
+	plan := Plan{}
+	add := func(change Change) {
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	for _, want := range spec.FromFields {
+		want := want
+		email := derefString(want.Email)
+		have, ok := st.fromFields[email]
+		if !ok {
+			add(Change{Action: ChangeCreate, Resource: ResourceFromField, Name: email, apply: func(ctx context.Context) glitch.DataError {
+				created, err := c.CreateFromField(ctx, FromField{Email: want.Email, Name: want.Name})
+				if err == nil && want.Default() {
+					_, err = c.SetDefaultFromField(ctx, created.FromFieldID)
+				}
+				return err
+			}})
+		} else if want.Default() && !have.Default() {
+			// from fields can't be edited, only made the default
+			add(Change{Action: ChangeUpdate, Resource: ResourceFromField, Name: email, ID: have.FromFieldID, Fields: []string{"isDefault"}, apply: func(ctx context.Context) glitch.DataError {
+				_, err := c.SetDefaultFromField(ctx, have.FromFieldID)
+				return err
+			}})
+		}
+	}
+
+	for _, want := range spec.Campaigns {
+		want := want
+		have, ok := st.campaigns[want.Name]
+		if !ok {
+			add(Change{Action: ChangeCreate, Resource: ResourceCampaign, Name: want.Name, apply: func(ctx context.Context) glitch.DataError {
+				created, err := c.CreateCampaign(ctx, want)
+				if err == nil {
+					st.campaignIDs[want.Name] = created.CampaignID
+				}
+				return err
+			}})
+		} else if fields := changedFields(want, have); len(fields) > 0 {
+			update := Campaign{}
+			onlyFields(want, fields, &update)
+			add(Change{Action: ChangeUpdate, Resource: ResourceCampaign, Name: want.Name, ID: have.CampaignID, Fields: fields, apply: func(ctx context.Context) glitch.DataError {
+				_, err := c.UpdateCampaign(ctx, have.CampaignID, update)
+				return err
+			}})
+		}
+	}
+
+	for _, want := range spec.CustomFields {
+		want := want
+		have, ok := st.customFields[want.Name]
+		if ok && want.Type != "" && want.Type != have.Type {
+			return Plan{}, newValidationError("Invalid spec", fmt.Sprintf("custom field %q is a %s field, GR can't change it to %s", want.Name, have.Type, want.Type))
+		}
+		if !ok {
+			add(Change{Action: ChangeCreate, Resource: ResourceCustomField, Name: want.Name, apply: func(ctx context.Context) glitch.DataError {
+				_, err := c.CreateCustomField(ctx, want)
+				return err
+			}})
+		} else if fields := changedFields(want, have); len(fields) > 0 {
+			update := CustomFieldDefinition{}
+			onlyFields(want, fields, &update)
+			add(Change{Action: ChangeUpdate, Resource: ResourceCustomField, Name: want.Name, ID: have.CustomFieldID, Fields: fields, apply: func(ctx context.Context) glitch.DataError {
+				_, err := c.UpdateCustomField(ctx, have.CustomFieldID, update)
+				return err
+			}})
+		}
+	}
+
+	for _, want := range spec.Tags {
+		want := want
+		name := derefString(want.Name)
+		have, ok := st.tags[name]
+		if !ok {
+			add(Change{Action: ChangeCreate, Resource: ResourceTag, Name: name, apply: func(ctx context.Context) glitch.DataError {
+				_, err := c.CreateTag(ctx, want)
+				return err
+			}})
+		} else if fields := changedFields(want, have); len(fields) > 0 {
+			update := Tag{}
+			onlyFields(want, fields, &update)
+			add(Change{Action: ChangeUpdate, Resource: ResourceTag, Name: name, ID: have.TagID, Fields: fields, apply: func(ctx context.Context) glitch.DataError {
+				_, err := c.UpdateTag(ctx, have.TagID, update)
+				return err
+			}})
+		}
+	}
+
+	planned := map[string]bool{}
+	for _, want := range spec.Campaigns {
+		planned[want.Name] = true
+	}
+	for _, as := range spec.Autoresponders {
+		as := as
+		name := as.Campaign + "/" + derefString(as.Name)
+		campaignID, ok := st.campaignIDs[as.Campaign]
+		if !ok && !planned[as.Campaign] {
+			return Plan{}, newValidationError("Invalid spec", fmt.Sprintf("autoresponder %q is in campaign %q, which neither exists nor is in the spec", derefString(as.Name), as.Campaign))
+		}
+		// the campaign ID is only known once the campaign exists, so look it up when applying
+		want := func() (Autoresponder, glitch.DataError) {
+			a := as.Autoresponder
+			id, ok := st.campaignIDs[as.Campaign]
+			if !ok {
+				return a, newValidationError("Missing campaign", fmt.Sprintf("campaign %q was not created", as.Campaign))
+			}
+			a.CampaignID = &id
+			return a, nil
+		}
+
+		have, ok := st.autoresponders[campaignID+"/"+derefString(as.Name)]
+		if !ok {
+			add(Change{Action: ChangeCreate, Resource: ResourceAutoresponder, Name: name, apply: func(ctx context.Context) glitch.DataError {
+				a, err := want()
+				if err != nil {
+					return err
+				}
+				_, err = c.CreateAutoresponder(ctx, a)
+				return err
+			}})
+			continue
+		}
+		compare := as.Autoresponder
+		compare.CampaignID = have.CampaignID
+		if fields := changedFields(compare, have); len(fields) > 0 {
+			update := Autoresponder{}
+			onlyFields(compare, fields, &update)
+			add(Change{Action: ChangeUpdate, Resource: ResourceAutoresponder, Name: name, ID: have.AutoresponderID, Fields: fields, apply: func(ctx context.Context) glitch.DataError {
+				_, err := c.UpdateAutoresponder(ctx, have.AutoresponderID, update)
+				return err
+			}})
+		}
+	}
+	return plan, nil
+}
+
+// changedFields returns the JSON fields set in want whose values differ in have, nested objects being compared
+// field by field
+func changedFields(want interface{}, have interface{}) []string {
+	w, h := map[string]interface{}{}, map[string]interface{}{}
+	wb, _ := json.Marshal(want)
+	hb, _ := json.Marshal(have)
+	json.Unmarshal(wb, &w)
+	json.Unmarshal(hb, &h)
+	ret := diffMaps(w, h, "")
+	sort.Strings(ret)
+	return ret
+}
+
+// onlyFields copies the top level JSON fields of src that changedFields reported into dst, so an update leaves the
+// rest, such as names and types GR won't change, out of the request
+func onlyFields(src interface{}, fields []string, dst interface{}) {
+	all := map[string]interface{}{}
+	b, _ := json.Marshal(src)
+	json.Unmarshal(b, &all)
+	changed := map[string]interface{}{}
+	for _, f := range fields {
+		k := strings.SplitN(f, ".", 2)[0]
+		changed[k] = all[k]
+	}
+	b, _ = json.Marshal(changed)
+	json.Unmarshal(b, dst)
+}
+
+func diffMaps(want map[string]interface{}, have map[string]interface{}, prefix string) []string {
+	ret := []string{}
+	for k, w := range want {
+		wm, wok := w.(map[string]interface{})
+		hm, hok := have[k].(map[string]interface{})
+		if wok && hok {
+			ret = append(ret, diffMaps(wm, hm, prefix+k+".")...)
+			continue
+		}
+		if !reflect.DeepEqual(w, have[k]) {
+			ret = append(ret, prefix+k)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,286 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+	"fromFields": [{"email": "news@example.com", "isDefault": "true"}, {"email": "deals@example.com", "name": "Deals"}],
+	"campaigns": [{"name": "news", "languageCode": "PL"}, {"name": "promo"}],
+	"customFields": [{"name": "plan", "type": "single_select", "hidden": "false", "values": ["basic", "pro"]}],
+	"tags": [{"name": "vip", "color": "#ff0000"}, {"name": "new"}],
+	"autoresponders": [
+		{"campaign": "news", "name": "Welcome", "subject": "Hi", "triggerSettings": {"type": "onday", "dayOfCycle": 0}},
+		{"campaign": "promo", "name": "Day 3", "subject": "Deals", "triggerSettings": {"type": "onday", "dayOfCycle": 3}}
+	]
+}`
+
+// reconcileServer fakes an account and records the requests changing it
+func reconcileServer(changes *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			fields := []string{}
+			for k := range body {
+				fields = append(fields, k)
+			}
+			sort.Strings(fields)
+			*changes = append(*changes, fmt.Sprintf("%s %v %v", r.URL.Path, fields, body["campaignId"]))
+			switch r.URL.Path {
+			case "/v3/from-fields":
+				fmt.Fprint(w, `{"fromFieldId": "f2"}`)
+			case "/v3/campaigns":
+				fmt.Fprint(w, `{"campaignId": "P", "name": "promo"}`)
+			default:
+				fmt.Fprint(w, `{}`)
+			}
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/from-fields":
+			fmt.Fprint(w, `[{"fromFieldId": "f1", "email": "news@example.com", "name": "News", "isDefault": "false"}]`)
+		case "/v3/campaigns":
+			fmt.Fprint(w, `[{"campaignId": "V", "name": "news", "languageCode": "EN", "isDefault": "true"}]`)
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId": "pl", "name": "plan", "type": "single_select", "hidden": "true", "values": ["basic", "pro"]}]`)
+		case "/v3/tags":
+			fmt.Fprint(w, `[{"tagId": "t1", "name": "vip", "color": "#00ff00"}]`)
+		case "/v3/autoresponders":
+			fmt.Fprint(w, `[{"autoresponderId": "Q", "campaignId": "V", "name": "Welcome", "subject": "Hello", "status": "enabled", "triggerSettings": {"type": "onday", "dayOfCycle": 0, "selectedDays": []}}]`)
+		default:
+			errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+		}
+	}))
+}
+
+func TestUnit_Reconcile(t *testing.T) {
+	spec, err := ParseSpec(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	changes := []string{}
+	ts := reconcileServer(&changes)
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	expectedPlan := `~ from field "news@example.com" (f1): isDefault
++ from field "deals@example.com"
+~ campaign "news" (V): languageCode
++ campaign "promo"
+~ custom field "plan" (pl): hidden
+~ tag "vip" (t1): color
++ tag "new"
+~ autoresponder "news/Welcome" (Q): subject
++ autoresponder "promo/Day 3"
+`
+	plan, err := PlanSpec(context.Background(), c, spec)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if plan.String() != expectedPlan {
+		t.Fatalf("Actual plan:\n%s\ndid not match expected:\n%s", plan, expectedPlan)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Expected planning to change nothing, got %v", changes)
+	}
+
+	plan, err = Reconcile(context.Background(), c, spec)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expectedChanges := []string{
+		"/v3/from-fields/f1/default [] <nil>",
+		"/v3/from-fields [email name] <nil>",
+		"/v3/campaigns/V [languageCode] <nil>",
+		"/v3/campaigns [name] <nil>",
+		"/v3/custom-fields/pl [hidden] <nil>",
+		"/v3/tags/t1 [color] <nil>",
+		"/v3/tags [name] <nil>",
+		"/v3/autoresponders/Q [subject] <nil>",
+		"/v3/autoresponders [campaignId name subject triggerSettings] P",
+	}
+	if len(plan.Changes) != 9 || !reflect.DeepEqual(expectedChanges, changes) {
+		t.Fatalf("Actual changes (%v) did not match expected (%v)", changes, expectedChanges)
+	}
+}
+
+func TestUnit_ReconcileFailure(t *testing.T) {
+	spec, _ := ParseSpec(strings.NewReader(`{"campaigns": [{"name": "promo"}], "autoresponders": [{"campaign": "promo", "name": "Day 3", "triggerSettings": {"type": "onday", "dayOfCycle": 3}}]}`))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if r.URL.Path == "/v3/autoresponders" {
+				t.Errorf("Expected no autoresponder without its campaign")
+			}
+			errorHandler(http.StatusBadRequest, ErrorResourceAlreadyExists)(w, r)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	plan, err := Reconcile(context.Background(), c, spec)
+	checkDataError(t, err, makeStringPtr("1008"))
+	if plan.Changes[0].Err == nil || plan.Changes[1].Err == nil || plan.Changes[1].Err.Code() != ErrorLocalValidation {
+		t.Fatalf("Unexpected plan %#v", plan)
+	}
+	if !strings.Contains(plan.String(), `+ campaign "promo" FAILED`) {
+		t.Fatalf("Expected the plan to show the failure, got %s", plan)
+	}
+}
+
+func TestUnit_InvalidSpec(t *testing.T) {
+
+	type testcase struct {
+		name string
+		spec string
+	}
+
+	testcases := []testcase{
+		testcase{name: "unknown field", spec: `{"campaigns": [{"name": "news", "colour": "red"}]}`},
+		testcase{name: "no name", spec: `{"tags": [{"color": "red"}]}`},
+		testcase{name: "duplicate", spec: `{"customFields": [{"name": "plan"}, {"name": "plan"}]}`},
+		testcase{name: "no campaign", spec: `{"autoresponders": [{"name": "Day 3", "triggerSettings": {"type": "onday", "dayOfCycle": 3}}]}`},
+		testcase{name: "invalid trigger", spec: `{"autoresponders": [{"campaign": "news", "name": "Day 3", "triggerSettings": {"type": "onday"}}]}`},
+		testcase{name: "unknown campaign", spec: `{"autoresponders": [{"campaign": "gone", "name": "Day 3", "triggerSettings": {"type": "onday", "dayOfCycle": 3}}]}`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := reconcileServer(&[]string{})
+			defer ts.Close()
+			c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+			spec, err := ParseSpec(strings.NewReader(tc.spec))
+			if err == nil {
+				_, err = PlanSpec(context.Background(), c, spec)
+			}
+			checkDataError(t, err, makeStringPtr(ErrorLocalValidation))
+		})
+	}
+}
+
+func TestUnit_PlanSpecCustomFieldType(t *testing.T) {
+	spec, _ := ParseSpec(strings.NewReader(`{"customFields": [{"name": "plan", "type": "text"}]}`))
+	changes := []string{}
+	ts := reconcileServer(&changes)
+	defer ts.Close()
+	c := NewClientWithOptions(WithBaseURL(ts.URL))
+
+	_, err := PlanSpec(context.Background(), c, spec)
+	checkDataError(t, err, makeStringPtr(ErrorLocalValidation))
+}
+
+func TestUnit_ParseSpec(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		spec            string
+		expectedErrCode *string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "json",
+			spec: testSpec,
+		},
+		testcase{
+			name: "yaml",
+			spec: `
+fromFields:
+  - {email: news@example.com, isDefault: "true"}
+  - {email: deals@example.com, name: Deals}
+campaigns:
+  - {name: news, languageCode: PL}
+  - name: promo
+customFields:
+  - name: plan
+    type: single_select
+    hidden: "false"
+    values: [basic, pro]
+tags:
+  - {name: vip, color: "#ff0000"}
+  - name: new
+autoresponders:
+  - campaign: news
+    name: Welcome
+    subject: Hi
+    triggerSettings: {type: onday, dayOfCycle: 0}
+  - campaign: promo
+    name: Day 3
+    subject: Deals
+    triggerSettings: {type: onday, dayOfCycle: 3}
+`,
+		},
+		testcase{
+			name:            "unknown yaml field",
+			spec:            "tags:\n  - name: vip\n    colour: red\n",
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "invalid yaml",
+			spec:            "tags: [vip",
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+	}
+
+	expected, _ := ParseSpec(strings.NewReader(testSpec))
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ret, err := ParseSpec(strings.NewReader(tc.spec))
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(expected, ret) {
+				t.Fatalf("Actual spec (%#v) did not match expected (%#v)", ret, expected)
+			}
+		})
+	}
+}
+
+func TestUnit_SpecValidate(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		spec            string
+		expectedErrCode *string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "same autoresponder name in two campaigns",
+			spec: `{"autoresponders": [{"campaign": "news", "name": "Welcome"}, {"campaign": "promo", "name": "Welcome"}]}`,
+		},
+		testcase{
+			name:            "unnamed autoresponders",
+			spec:            `{"autoresponders": [{"campaign": "news"}, {"campaign": "news"}]}`,
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "duplicate autoresponder",
+			spec:            `{"autoresponders": [{"campaign": "news", "name": "Welcome"}, {"campaign": "news", "name": "Welcome"}]}`,
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "duplicate tag",
+			spec:            `{"tags": [{"name": "vip"}, {"name": "vip"}]}`,
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := ParseSpec(strings.NewReader(tc.spec))
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			checkDataError(t, spec.validate(), tc.expectedErrCode)
+		})
+	}
+}
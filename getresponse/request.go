@@ -0,0 +1,146 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/healthimation/go-client/client"
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// do makes an authenticated request to the GR api.  bodyObj, if not nil, is sent as JSON and a successful
+// response is unmarshalled into result if it is not nil.  Error responses are converted with parseError.
+func (g *getResponseClient) do(ctx context.Context, method string, slug string, query url.Values, bodyObj interface{}, result interface{}) glitch.DataError {
+	_, err := g.doWithHeaders(ctx, method, slug, query, bodyObj, result)
+	return err
+}
+
+// doWithHeaders is do but also returns the response headers, which carry paging information
+func (g *getResponseClient) doWithHeaders(ctx context.Context, method string, slug string, query url.Values, bodyObj interface{}, result interface{}) (http.Header, glitch.DataError) {
+	h := http.Header{}
+	h.Set("Content-type", "application/json")
+	if err := g.authorize(ctx, h); err != nil {
+		return nil, err
+	}
+	if g.domain != "" {
+		h.Set("X-Domain", g.domain)
+	}
+	if g.userAgent != "" {
+		h.Set("User-Agent", g.userAgent)
+	}
+
+	var body []byte
+	if bodyObj != nil {
+		var err error
+		body, err = json.Marshal(bodyObj)
+		if err != nil {
+			return nil, glitch.NewDataError(err, client.ErrorMarshallingObject, "Error marshalling object to json")
+		}
+	}
+
+	status, respHeaders, ret, err := g.makeRequestWithRetry(ctx, method, slug, query, h, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if status < 200 || status >= 400 {
+		//parse error
+		return respHeaders, g.parseError(ret)
+	}
+
+	if result == nil {
+		return respHeaders, nil
+	}
+
+	jErr := json.Unmarshal(ret, result)
+	if jErr != nil {
+		return respHeaders, glitch.NewDataError(jErr, client.ErrorDecodingResponse, fmt.Sprintf("Could not unmarshal response: %s", ret))
+	}
+
+	return respHeaders, nil
+}
+
+// makeRequest does a single round trip and returns the status, headers and body of the response
+func (g *getResponseClient) makeRequest(ctx context.Context, method string, slug string, query url.Values, headers http.Header, body []byte) (int, http.Header, []byte, glitch.DataError) {
+	u, err := g.finder("getresponse", true)
+	if err != nil {
+		return 0, nil, nil, glitch.NewDataError(err, client.ErrorCantFind, "Error finding service")
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + slug
+	u.RawQuery = query.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, u.String(), reader)
+	if err != nil {
+		return 0, nil, nil, glitch.NewDataError(err, client.ErrorRequestCreation, "Error creating request object")
+	}
+	req.Header = headers
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	if g.hooks.BeforeRequest != nil {
+		g.hooks.BeforeRequest(req)
+	}
+
+	start := time.Now()
+	resp, err := g.httpClient.Do(req)
+	if g.hooks.AfterResponse != nil {
+		g.hooks.AfterResponse(req, resp, err)
+	}
+	if err != nil {
+		g.logf("getresponse: %s %s failed after %s: %s", method, slug, time.Since(start), err)
+		return 0, nil, nil, glitch.NewDataError(err, client.ErrorRequestError, "Could not make the request")
+	}
+	defer resp.Body.Close()
+
+	ret, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, glitch.NewDataError(err, client.ErrorDecodingResponse, "Could not read response body")
+	}
+	g.logf("getresponse: %s %s returned %d in %s", method, slug, resp.StatusCode, time.Since(start))
+
+	return resp.StatusCode, resp.Header, ret, nil
+}
+
+func (g *getResponseClient) logf(format string, v ...interface{}) {
+	if g.logger != nil {
+		g.logger.Printf(format, v...)
+	}
+}
+
+// listQuery builds the query parameters shared by the GR collection endpoints
+func listQuery(queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) url.Values {
+	query := fieldsQuery(fields)
+	for k, v := range queryHash {
+		query.Set(fmt.Sprintf("query[%s]", k), v)
+	}
+
+	for k, v := range sortHash {
+		query.Set(fmt.Sprintf("sort[%s]", k), v)
+	}
+
+	query.Set("page", strconv.Itoa(int(page)))
+	query.Set("perPage", strconv.Itoa(int(perPage)))
+	return query
+}
+
+func fieldsQuery(fields []string) url.Values {
+	query := url.Values{}
+	if len(fields) > 0 {
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	return query
+}
@@ -0,0 +1,164 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// RetryPolicy controls how the client retries requests.  The zero value never retries.
+//
+// Requests rejected with ErrorRequestQuotaReached (or a 429) are retried for any method once the
+// X-RateLimit-Reset window has passed, or after the backoff (at least a second) when GR sends no reset.  Requests failing with a 5xx or ErrorInternalError are only
+// retried for idempotent methods, with exponential backoff.  No retry is attempted when the wait would
+// run past the context deadline.
+type RetryPolicy struct {
+	MaxRetries   int           // how many times a request is retried, 0 disables retries
+	MinBackoff   time.Duration // wait before the first retry of a server error, doubled on each attempt
+	MaxBackoff   time.Duration // cap on the server error backoff
+	MaxQuotaWait time.Duration // longest wait for a quota reset, 0 means only the context deadline limits it
+}
+
+// DefaultRetryPolicy is a sensible policy for long running jobs
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:   5,
+	MinBackoff:   500 * time.Millisecond,
+	MaxBackoff:   30 * time.Second,
+	MaxQuotaWait: 10 * time.Minute,
+}
+
+// minQuotaWait is the wait before retrying a quota error when neither the X-RateLimit-Reset header nor
+// MinBackoff gives one
+const minQuotaWait = time.Second
+
+// RateLimit holds the X-RateLimit-* headers GR sends with every response
+type RateLimit struct {
+	Limit     int           // calls allowed in the current time frame
+	Remaining int           // calls left in the current time frame
+	Reset     time.Duration // time until the current time frame ends
+}
+
+// rateLimitFromHeader parses the rate limit headers, reporting false if they are missing.
+// GR sends the reset as "413 seconds".
+func rateLimitFromHeader(h http.Header) (RateLimit, bool) {
+	ret := RateLimit{}
+	limit, limitErr := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if limitErr != nil || remainingErr != nil {
+		return ret, false
+	}
+	ret.Limit = limit
+	ret.Remaining = remaining
+
+	reset := strings.Fields(h.Get("X-RateLimit-Reset"))
+	if len(reset) > 0 {
+		if seconds, err := strconv.Atoi(reset[0]); err == nil {
+			ret.Reset = time.Duration(seconds) * time.Second
+		}
+	}
+	return ret, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before the given (0 based) retry of a server error
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinBackoff
+	for i := 0; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// delay decides whether a failed response should be retried and how long to wait first
+func (p RetryPolicy) delay(attempt int, method string, status int, h http.Header, body []byte) (time.Duration, bool) {
+	if attempt >= p.MaxRetries {
+		return 0, false
+	}
+
+	errRet := ErrorResponse{}
+	json.Unmarshal(body, &errRet)
+
+	switch {
+	case status == http.StatusTooManyRequests || errRet.ErrorCode == ErrorRequestQuotaReached:
+		wait := p.backoff(attempt)
+		if rl, ok := rateLimitFromHeader(h); ok && rl.Reset > 0 {
+			wait = rl.Reset
+		}
+		if wait <= 0 {
+			wait = minQuotaWait
+		}
+		if p.MaxQuotaWait > 0 && wait > p.MaxQuotaWait {
+			return 0, false
+		}
+		return wait, true
+	case status >= 500 || errRet.ErrorCode == ErrorInternalError:
+		return p.backoff(attempt), isIdempotent(method)
+	}
+	return 0, false
+}
+
+// makeRequestWithRetry is makeRequest throttled by the client's RateLimiter and retried according to its RetryPolicy.  When it gives up the
+// last response is returned as is.
+func (g *getResponseClient) makeRequestWithRetry(ctx context.Context, method string, slug string, query url.Values, headers http.Header, body []byte) (int, http.Header, []byte, glitch.DataError) {
+	for attempt := 0; ; attempt++ {
+		if g.limiter != nil {
+			if err := g.limiter.Wait(ctx); err != nil {
+				return 0, nil, nil, err
+			}
+		}
+
+		status, respHeaders, ret, err := g.makeRequest(ctx, method, slug, query, headers, body)
+		if g.limiter != nil && err == nil {
+			if rl, ok := rateLimitFromHeader(respHeaders); ok {
+				g.limiter.observe(rl)
+			}
+		}
+		if err != nil || (status >= 200 && status < 400) {
+			return status, respHeaders, ret, err
+		}
+
+		wait, retry := g.retry.delay(attempt, method, status, respHeaders, ret)
+		if !retry {
+			return status, respHeaders, ret, err
+		}
+		g.logf("getresponse: retrying %s %s in %s after status %d", method, slug, wait, status)
+		if !sleepContext(ctx, wait) {
+			return status, respHeaders, ret, err
+		}
+	}
+}
+
+// sleepContext waits for d unless the context would be done first, in which case it returns false straight away
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return false
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,202 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// failingHandler fails the first `failures` calls with the given status and body and then succeeds
+func failingHandler(failures int, status int, resetHeader string, body string, calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("X-RateLimit-Limit", "30000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", resetHeader)
+		if *calls <= failures {
+			w.WriteHeader(status)
+			fmt.Fprint(w, body)
+			return
+		}
+		fmt.Fprint(w, `{"name": "foobar", "email": "foo@bar.baz"}`)
+	}
+}
+
+func TestUnit_Retry(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		failures        int
+		status          int
+		resetHeader     string
+		body            string
+		policy          RetryPolicy
+		ctxTimeout      time.Duration
+		call            func(ctx context.Context, c Client) error
+		expectedCalls   int
+		expectedErrCode *string
+	}
+
+	get := func(ctx context.Context, c Client) error {
+		_, err := c.GetContact(ctx, "foo", nil)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	update := func(ctx context.Context, c Client) error {
+		_, err := c.UpdateContact(ctx, "foo", Contact{})
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	policy := RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	testcases := []testcase{
+		testcase{
+			name:          "quota reached is retried after reset",
+			failures:      2,
+			status:        http.StatusTooManyRequests,
+			resetHeader:   "0 seconds",
+			body:          `{"code":1015}`,
+			policy:        policy,
+			call:          update,
+			expectedCalls: 3,
+		},
+		testcase{
+			name:          "internal error is retried for idempotent calls",
+			failures:      1,
+			status:        http.StatusInternalServerError,
+			body:          `{"code":1}`,
+			policy:        policy,
+			call:          get,
+			expectedCalls: 2,
+		},
+		testcase{
+			name:            "internal error is not retried for non idempotent calls",
+			failures:        1,
+			status:          http.StatusInternalServerError,
+			body:            `{"code":1}`,
+			policy:          policy,
+			call:            update,
+			expectedCalls:   1,
+			expectedErrCode: makeStringPtr("1"),
+		},
+		testcase{
+			name:            "gives up after max retries",
+			failures:        5,
+			status:          http.StatusServiceUnavailable,
+			body:            `{"code":1}`,
+			policy:          policy,
+			call:            get,
+			expectedCalls:   4,
+			expectedErrCode: makeStringPtr("1"),
+		},
+		testcase{
+			name:            "gives up when reset is past the deadline",
+			failures:        1,
+			status:          http.StatusTooManyRequests,
+			resetHeader:     "60 seconds",
+			body:            `{"code":1015}`,
+			policy:          policy,
+			ctxTimeout:      time.Second,
+			call:            get,
+			expectedCalls:   1,
+			expectedErrCode: makeStringPtr("1015"),
+		},
+		testcase{
+			name:            "quota reached without a reset backs off",
+			failures:        5,
+			status:          http.StatusTooManyRequests,
+			body:            `{"code":1015}`,
+			policy:          RetryPolicy{MaxRetries: 3, MinBackoff: time.Minute, MaxBackoff: time.Minute},
+			ctxTimeout:      time.Second,
+			call:            get,
+			expectedCalls:   1,
+			expectedErrCode: makeStringPtr("1015"),
+		},
+		testcase{
+			name:            "quota reached without a reset or backoff waits a second",
+			failures:        1,
+			status:          http.StatusTooManyRequests,
+			body:            `{"code":1015}`,
+			policy:          RetryPolicy{MaxRetries: 5},
+			ctxTimeout:      500 * time.Millisecond,
+			call:            get,
+			expectedCalls:   1,
+			expectedErrCode: makeStringPtr("1015"),
+		},
+		testcase{
+			name:            "zero policy does not retry",
+			failures:        1,
+			status:          http.StatusTooManyRequests,
+			resetHeader:     "0 seconds",
+			body:            `{"code":1015}`,
+			call:            get,
+			expectedCalls:   1,
+			expectedErrCode: makeStringPtr("1015"),
+		},
+		testcase{
+			name:            "validation errors are not retried",
+			failures:        1,
+			status:          http.StatusBadRequest,
+			body:            `{"code":1000}`,
+			policy:          policy,
+			call:            get,
+			expectedCalls:   1,
+			expectedErrCode: makeStringPtr("1000"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			ts := httptest.NewServer(failingHandler(tc.failures, tc.status, tc.resetHeader, tc.body, &calls))
+			c := NewClientWithOptions(WithBaseURL(ts.URL), WithRetryPolicy(tc.policy))
+			defer ts.Close()
+
+			ctx := context.Background()
+			if tc.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.ctxTimeout)
+				defer cancel()
+			}
+
+			err := tc.call(ctx, c)
+			if calls != tc.expectedCalls {
+				t.Fatalf("Actual calls (%d) did not match expected (%d)", calls, tc.expectedCalls)
+			}
+			if tc.expectedErrCode != nil || err != nil {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if code := err.(interface{ Code() string }).Code(); code != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", code, *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUnit_RateLimitFromHeader(t *testing.T) {
+	h := http.Header{}
+	if _, ok := rateLimitFromHeader(h); ok {
+		t.Fatalf("Expected missing headers to be reported")
+	}
+
+	h.Set("X-RateLimit-Limit", "30000")
+	h.Set("X-RateLimit-Remaining", "29890")
+	h.Set("X-RateLimit-Reset", "413 seconds")
+	rl, ok := rateLimitFromHeader(h)
+	expected := RateLimit{Limit: 30000, Remaining: 29890, Reset: 413 * time.Second}
+	if !ok || rl != expected {
+		t.Fatalf("Actual rate limit (%#v) did not match expected (%#v)", rl, expected)
+	}
+}
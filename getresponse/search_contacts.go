@@ -0,0 +1,216 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// Logic operators joining sections and conditions
+const (
+	LogicAnd = "and"
+	LogicOr  = "or"
+)
+
+// Subscriber types a search is limited to
+const (
+	SubscribersTypeSubscribed  = "subscribed"
+	SubscribersTypeUndelivered = "undelivered"
+	SubscribersTypeRemoved     = "removed"
+	SubscribersTypeUnconfirmed = "unconfirmed"
+)
+
+// Condition types
+const (
+	ConditionTypeEmail   = "email"
+	ConditionTypeName    = "name"
+	ConditionTypeCustom  = "custom"
+	ConditionTypeTag     = "tag"
+	ConditionTypeOpened  = "opened"
+	ConditionTypeClicked = "clicked"
+)
+
+// Operator types
+const (
+	OperatorTypeString  = "string_operator"
+	OperatorTypeNumeric = "numeric_operator"
+	OperatorTypeDate    = "date_operator"
+)
+
+// Operators
+const (
+	OperatorIs          = "is"
+	OperatorIsNot       = "is_not"
+	OperatorContains    = "contains"
+	OperatorNotContains = "not_contains"
+	OperatorStarts      = "starts"
+	OperatorEnds        = "ends"
+	OperatorLess        = "less"
+	OperatorGreater     = "greater"
+	OperatorExists      = "exists"
+	OperatorNotExists   = "not_exists"
+	OperatorOpened      = "opened"
+	OperatorNotOpened   = "not_opened"
+	OperatorClicked     = "clicked"
+	OperatorNotClicked  = "not_clicked"
+)
+
+// EmailCondition compares the contact's email with a string operator, e.g. OperatorContains
+func EmailCondition(operator string, value string) SearchContactsCondition {
+	return stringCondition(ConditionTypeEmail, operator, value)
+}
+
+// NameCondition compares the contact's name with a string operator
+func NameCondition(operator string, value string) SearchContactsCondition {
+	return stringCondition(ConditionTypeName, operator, value)
+}
+
+// CustomFieldCondition compares the contact's value of a custom field.  operatorType is one of the OperatorType*
+// constants and should match the type of the field.
+func CustomFieldCondition(customFieldID string, operatorType string, operator string, value string) SearchContactsCondition {
+	c := stringCondition(ConditionTypeCustom, operator, value)
+	c.Scope = &customFieldID
+	c.OperatorType = &operatorType
+	return c
+}
+
+// TagCondition matches contacts that have the tag, or that don't if has is false
+func TagCondition(tagID string, has bool) SearchContactsCondition {
+	operator := OperatorExists
+	if !has {
+		operator = OperatorNotExists
+	}
+	return SearchContactsCondition{ConditionType: ConditionTypeTag, Operator: operator, Value: &tagID}
+}
+
+// OpenedCondition matches contacts that opened the message, or that didn't if opened is false
+func OpenedCondition(messageID string, opened bool) SearchContactsCondition {
+	operator := OperatorOpened
+	if !opened {
+		operator = OperatorNotOpened
+	}
+	return SearchContactsCondition{ConditionType: ConditionTypeOpened, Operator: operator, Value: &messageID}
+}
+
+// ClickedCondition matches contacts that clicked a link in the message, or that didn't if clicked is false
+func ClickedCondition(messageID string, clicked bool) SearchContactsCondition {
+	operator := OperatorClicked
+	if !clicked {
+		operator = OperatorNotClicked
+	}
+	return SearchContactsCondition{ConditionType: ConditionTypeClicked, Operator: operator, Value: &messageID}
+}
+
+func stringCondition(conditionType string, operator string, value string) SearchContactsCondition {
+	operatorType := OperatorTypeString
+	return SearchContactsCondition{ConditionType: conditionType, OperatorType: &operatorType, Operator: operator, Value: &value}
+}
+
+func (g *getResponseClient) GetSearchContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]SearchContact, glitch.DataError) {
+	result := make([]SearchContact, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/search-contacts", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateSearchContacts(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *SearchContactIterator {
+	it := &SearchContactIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]SearchContact, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/search-contacts", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetSearchContact(ctx context.Context, ID string, fields []string) (SearchContact, glitch.DataError) {
+	result := SearchContact{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/search-contacts/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) CreateSearchContact(ctx context.Context, search SearchContact) (SearchContact, glitch.DataError) {
+	result := SearchContact{}
+	err := g.do(ctx, http.MethodPost, "/v3/search-contacts", nil, search, &result)
+	return result, err
+}
+
+func (g *getResponseClient) UpdateSearchContact(ctx context.Context, ID string, newData SearchContact) (SearchContact, glitch.DataError) {
+	result := SearchContact{}
+	err := g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/search-contacts/%s", ID), nil, newData, &result)
+	return result, err
+}
+
+func (g *getResponseClient) DeleteSearchContact(ctx context.Context, ID string) glitch.DataError {
+	return g.do(ctx, http.MethodDelete, fmt.Sprintf("/v3/search-contacts/%s", ID), nil, nil, nil)
+}
+
+func (g *getResponseClient) GetSearchContactsContacts(ctx context.Context, ID string, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Contact, glitch.DataError) {
+	result := make([]Contact, 0)
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/search-contacts/%s/contacts", ID), listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateSearchContactsContacts(ctx context.Context, ID string, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *ContactIterator {
+	it := &ContactIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Contact, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, fmt.Sprintf("/v3/search-contacts/%s/contacts", ID), listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) SearchContactsByConditions(ctx context.Context, conditions SearchContactsConditions, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Contact, glitch.DataError) {
+	result := make([]Contact, 0)
+	err := g.do(ctx, http.MethodPost, "/v3/search-contacts/contacts", listQuery(nil, fields, sortHash, page, perPage), conditions, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateSearchContactsByConditions(ctx context.Context, conditions SearchContactsConditions, fields []string, sortHash map[string]string, perPage int32) *ContactIterator {
+	it := &ContactIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Contact, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodPost, "/v3/search-contacts/contacts", listQuery(nil, fields, sortHash, page, perPage), conditions, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+// SearchContactIterator walks the pages of a saved search listing.  Call Next until it returns false and then check
+// Err.
+type SearchContactIterator struct {
+	p   *pager
+	buf []SearchContact
+	cur SearchContact
+}
+
+// Next advances to the next saved search, fetching the next page when needed
+func (it *SearchContactIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// SearchContact returns the saved search the iterator is positioned at
+func (it *SearchContactIterator) SearchContact() SearchContact {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SearchContactIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *SearchContactIterator) Pagination() Pagination {
+	return it.p.pagination
+}
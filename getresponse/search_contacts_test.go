@@ -0,0 +1,218 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_SearchContactCRUD(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		call             func(c Client) (SearchContact, error)
+		expectedMethod   string
+		expectedPath     string
+		expectedResponse SearchContact
+	}
+
+	ctx := context.Background()
+	search := SearchContact{
+		Name:                 makeStringPtr("vips"),
+		SubscribersType:      []string{SubscribersTypeSubscribed},
+		SectionLogicOperator: makeStringPtr(LogicOr),
+		Section: []SearchContactsSection{SearchContactsSection{
+			CampaignIDsList: []string{"V"},
+			LogicOperator:   LogicAnd,
+			Conditions:      []SearchContactsCondition{TagCondition("vBd5", true)},
+		}},
+	}
+	testcases := []testcase{
+		testcase{
+			name:             "get",
+			call:             func(c Client) (SearchContact, error) { return c.GetSearchContact(ctx, "pV3r", nil) },
+			expectedMethod:   http.MethodGet,
+			expectedPath:     "/v3/search-contacts/pV3r",
+			expectedResponse: SearchContact{SearchContactID: "pV3r", Name: makeStringPtr("vips")},
+		},
+		testcase{
+			name:             "create",
+			call:             func(c Client) (SearchContact, error) { return c.CreateSearchContact(ctx, search) },
+			expectedMethod:   http.MethodPost,
+			expectedPath:     "/v3/search-contacts",
+			expectedResponse: SearchContact{SearchContactID: "pV3r", Name: makeStringPtr("vips")},
+		},
+		testcase{
+			name:             "update",
+			call:             func(c Client) (SearchContact, error) { return c.UpdateSearchContact(ctx, "pV3r", search) },
+			expectedMethod:   http.MethodPost,
+			expectedPath:     "/v3/search-contacts/pV3r",
+			expectedResponse: SearchContact{SearchContactID: "pV3r", Name: makeStringPtr("vips")},
+		},
+		testcase{
+			name: "delete",
+			call: func(c Client) (SearchContact, error) {
+				if err := c.DeleteSearchContact(ctx, "pV3r"); err != nil {
+					return SearchContact{}, err
+				}
+				return SearchContact{}, nil
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/v3/search-contacts/pV3r",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tc.expectedMethod || r.URL.Path != tc.expectedPath {
+					errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+					return
+				}
+				if r.Method != http.MethodDelete {
+					fmt.Fprint(w, `{"searchContactId": "pV3r", "name": "vips"}`)
+				}
+			}), 5*time.Second)
+			defer ts.Close()
+			ret, err := tc.call(c)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_SearchContactsConditionJSON(t *testing.T) {
+
+	type testcase struct {
+		name         string
+		condition    SearchContactsCondition
+		expectedJSON string
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:         "email",
+			condition:    EmailCondition(OperatorContains, "@example.com"),
+			expectedJSON: `{"conditionType":"email","operatorType":"string_operator","operator":"contains","value":"@example.com"}`,
+		},
+		testcase{
+			name:         "custom field",
+			condition:    CustomFieldCondition("pas", OperatorTypeNumeric, OperatorGreater, "10"),
+			expectedJSON: `{"conditionType":"custom","scope":"pas","operatorType":"numeric_operator","operator":"greater","value":"10"}`,
+		},
+		testcase{
+			name:         "without tag",
+			condition:    TagCondition("vBd5", false),
+			expectedJSON: `{"conditionType":"tag","operator":"not_exists","value":"vBd5"}`,
+		},
+		testcase{
+			name:         "opened",
+			condition:    OpenedCondition("Xy", true),
+			expectedJSON: `{"conditionType":"opened","operator":"opened","value":"Xy"}`,
+		},
+		testcase{
+			name:         "not clicked",
+			condition:    ClickedCondition("Xy", false),
+			expectedJSON: `{"conditionType":"clicked","operator":"not_clicked","value":"Xy"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.condition)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if string(b) != tc.expectedJSON {
+				t.Fatalf("Actual JSON (%s) did not match expected (%s)", b, tc.expectedJSON)
+			}
+		})
+	}
+}
+
+func TestUnit_SearchContactsByConditions(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse []Contact
+	}
+
+	conditions := SearchContactsConditions{
+		SubscribersType:      []string{SubscribersTypeSubscribed},
+		SectionLogicOperator: LogicOr,
+		Section: []SearchContactsSection{SearchContactsSection{
+			CampaignIDsList: []string{"V"},
+			LogicOperator:   LogicAnd,
+			Conditions:      []SearchContactsCondition{EmailCondition(OperatorEnds, "@example.com")},
+		}},
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := SearchContactsConditions{}
+				b, _ := ioutil.ReadAll(r.Body)
+				if r.Method != http.MethodPost || r.URL.Path != "/v3/search-contacts/contacts" || r.URL.Query().Get("perPage") != "10" ||
+					json.Unmarshal(b, &body) != nil || !reflect.DeepEqual(conditions, body) {
+					errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+					return
+				}
+				fmt.Fprint(w, `[{"contactId": "abc"}]`)
+			}),
+			expectedResponse: []Contact{Contact{ContactID: makeStringPtr("abc")}},
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusBadRequest, ErrorValidationError),
+			expectedErrCode: makeStringPtr("1000"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.SearchContactsByConditions(context.Background(), conditions, nil, nil, 1, 10)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_IterateSearchContactsContacts(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/search-contacts/pV3r/contacts" {
+			errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+			return
+		}
+		w.Header().Set("TotalPages", "2")
+		w.Header().Set("CurrentPage", r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `[{"contactId": "c%s"}]`, r.URL.Query().Get("page"))
+	}), 5*time.Second)
+	defer ts.Close()
+
+	it := c.IterateSearchContactsContacts(context.Background(), "pV3r", nil, nil, nil, 1)
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, *it.Contact().ContactID)
+	}
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error: %v", it.Err())
+	}
+	if !reflect.DeepEqual([]string{"c1", "c2"}, ids) {
+		t.Fatalf("Unexpected contacts %v", ids)
+	}
+}
@@ -0,0 +1,167 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+// Statistics groupings
+const (
+	GroupByHour  = "hour"
+	GroupByDay   = "day"
+	GroupByMonth = "month"
+	GroupByTotal = "total"
+)
+
+// StatisticsFilter selects and groups newsletter or autoresponder statistics.  The zero value returns the totals
+// of all time.
+type StatisticsFilter struct {
+	GroupBy          string     // one of the GroupBy* constants, GR defaults to GroupByTotal
+	From             *time.Time // only statistics on or after this day
+	To               *time.Time // only statistics on or before this day
+	NewsletterIDs    []string   // only these newsletters, for the statistics of several newsletters
+	AutoresponderIDs []string   // only these autoresponders, for the statistics of several autoresponders
+	CampaignIDs      []string   // only messages of these campaigns, for the statistics of several messages
+}
+
+func (f StatisticsFilter) query() (url.Values, glitch.DataError) {
+	query := url.Values{}
+	switch f.GroupBy {
+	case "":
+	case GroupByHour, GroupByDay, GroupByMonth, GroupByTotal:
+		query.Set("query[groupBy]", f.GroupBy)
+	default:
+		return nil, newValidationError("Invalid statistics filter", fmt.Sprintf("statistics can't be grouped by %q", f.GroupBy))
+	}
+	if f.From != nil && f.To != nil && f.From.After(*f.To) {
+		return nil, newValidationError("Invalid statistics filter", fmt.Sprintf("from %s is after to %s", f.From.Format(CustomFieldDateFormat), f.To.Format(CustomFieldDateFormat)))
+	}
+	if f.From != nil {
+		query.Set("query[createdOn][from]", f.From.Format(CustomFieldDateFormat))
+	}
+	if f.To != nil {
+		query.Set("query[createdOn][to]", f.To.Format(CustomFieldDateFormat))
+	}
+	if len(f.NewsletterIDs) > 0 {
+		query.Set("query[newsletterId]", strings.Join(f.NewsletterIDs, ","))
+	}
+	if len(f.AutoresponderIDs) > 0 {
+		query.Set("query[autoresponderId]", strings.Join(f.AutoresponderIDs, ","))
+	}
+	if len(f.CampaignIDs) > 0 {
+		query.Set("query[campaignId]", strings.Join(f.CampaignIDs, ","))
+	}
+	return query, nil
+}
+
+// TimeInterval is the period a row of statistics covers
+type TimeInterval struct {
+	From Timestamp
+	To   Timestamp
+}
+
+// MarshalJSON writes the interval the way GR does, as from/to
+func (i TimeInterval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.From.Format(TimestampFormat) + "/" + i.To.Format(TimestampFormat))
+}
+
+// UnmarshalJSON reads an interval such as 2018-01-01T00:00:00+0000/2018-01-31T23:59:59+0000
+func (i *TimeInterval) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("unrecognized time interval %q", s)
+	}
+	from, err := ParseTimestamp(parts[0])
+	if err != nil {
+		return err
+	}
+	to, err := ParseTimestamp(parts[1])
+	if err != nil {
+		return err
+	}
+	i.From, i.To = from, to
+	return nil
+}
+
+// StatisticsSummary adds up rows of statistics, e.g. to report on a newsletter or a month of them
+type StatisticsSummary struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Sent          int64     `json:"sent"`
+	TotalOpened   int64     `json:"totalOpened"`
+	UniqueOpened  int64     `json:"uniqueOpened"`
+	TotalClicked  int64     `json:"totalClicked"`
+	UniqueClicked int64     `json:"uniqueClicked"`
+	Goals         int64     `json:"goals"`
+	UniqueGoals   int64     `json:"uniqueGoals"`
+	Forwarded     int64     `json:"forwarded"`
+	Unsubscribed  int64     `json:"unsubscribed"`
+	Bounced       int64     `json:"bounced"`
+	Complaints    int64     `json:"complaints"`
+	OpenRate      float64   `json:"openRate"`      // UniqueOpened per message sent
+	ClickRate     float64   `json:"clickRate"`     // UniqueClicked per message sent
+	BounceRate    float64   `json:"bounceRate"`    // Bounced per message sent
+	ComplaintRate float64   `json:"complaintRate"` // Complaints per message sent
+}
+
+// SummarizeStatistics adds up the rows, which may come from several messages, into a summary spanning them all
+func SummarizeStatistics(rows []MessageStatistics) StatisticsSummary {
+	s := StatisticsSummary{}
+	for _, r := range rows {
+		if !r.TimeInterval.From.IsZero() && (s.From.IsZero() || r.TimeInterval.From.Before(s.From)) {
+			s.From = r.TimeInterval.From.Time
+		}
+		if r.TimeInterval.To.After(s.To) {
+			s.To = r.TimeInterval.To.Time
+		}
+		s.Sent += r.Sent
+		s.TotalOpened += r.TotalOpened
+		s.UniqueOpened += r.UniqueOpened
+		s.TotalClicked += r.TotalClicked
+		s.UniqueClicked += r.UniqueClicked
+		s.Goals += r.Goals
+		s.UniqueGoals += r.UniqueGoals
+		s.Forwarded += r.Forwarded
+		s.Unsubscribed += r.Unsubscribed
+		s.Bounced += r.Bounced
+		s.Complaints += r.Complaints
+	}
+	if s.Sent > 0 {
+		sent := float64(s.Sent)
+		s.OpenRate = float64(s.UniqueOpened) / sent
+		s.ClickRate = float64(s.UniqueClicked) / sent
+		s.BounceRate = float64(s.Bounced) / sent
+		s.ComplaintRate = float64(s.Complaints) / sent
+	}
+	return s
+}
+
+// getStatistics fetches the statistics at path
+func (g *getResponseClient) getStatistics(ctx context.Context, path string, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError) {
+	result := make([]MessageStatistics, 0)
+	query, err := filter.query()
+	if err != nil {
+		return result, err
+	}
+	err = g.do(ctx, http.MethodGet, path, query, nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) GetNewsletterStatistics(ctx context.Context, ID string, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError) {
+	return g.getStatistics(ctx, fmt.Sprintf("/v3/newsletters/%s/statistics", ID), filter)
+}
+
+func (g *getResponseClient) GetNewslettersStatistics(ctx context.Context, filter StatisticsFilter) ([]MessageStatistics, glitch.DataError) {
+	return g.getStatistics(ctx, "/v3/newsletters/statistics", filter)
+}
@@ -0,0 +1,139 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testStatistics = `[
+	{"timeInterval": "2018-01-01T00:00:00+0000/2018-01-01T23:59:59+0000", "sent": 100, "totalOpened": 60, "uniqueOpened": 40, "totalClicked": 12, "uniqueClicked": 10, "bounced": 2, "complaints": 1},
+	{"timeInterval": "2018-01-02T00:00:00+0000/2018-01-02T23:59:59+0000", "sent": 100, "totalOpened": 30, "uniqueOpened": 20, "totalClicked": 6, "uniqueClicked": 5, "unsubscribed": 3}
+]`
+
+func TestUnit_GetNewsletterStatistics(t *testing.T) {
+
+	type testcase struct {
+		name            string
+		handler         http.HandlerFunc
+		filter          StatisticsFilter
+		expectedErrCode *string
+		expectedRows    int
+	}
+
+	from := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if r.URL.Path != "/v3/newsletters/n1/statistics" || q.Get("query[groupBy]") != "day" || q.Get("query[createdOn][from]") != "2018-01-01" || q.Get("query[createdOn][to]") != "2018-01-02" {
+					errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+					return
+				}
+				fmt.Fprint(w, testStatistics)
+			}),
+			filter:       StatisticsFilter{GroupBy: GroupByDay, From: &from, To: &to},
+			expectedRows: 2,
+		},
+		testcase{
+			name:            "invalid grouping",
+			handler:         http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { t.Errorf("Unexpected request") }),
+			filter:          StatisticsFilter{GroupBy: "week"},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "from after to",
+			handler:         http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { t.Errorf("Unexpected request") }),
+			filter:          StatisticsFilter{From: &to, To: &from},
+			expectedErrCode: makeStringPtr(ErrorLocalValidation),
+		},
+		testcase{
+			name:            "invalid time interval",
+			handler:         http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, `[{"timeInterval": "yesterday"}]`) }),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_RESPONSE"),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusNotFound, ErrorResourceNotFound),
+			expectedErrCode: makeStringPtr("1013"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetNewsletterStatistics(context.Background(), "n1", tc.filter)
+			if checkDataError(t, err, tc.expectedErrCode) && len(ret) != tc.expectedRows {
+				t.Fatalf("Expected %d rows, got %#v", tc.expectedRows, ret)
+			}
+		})
+	}
+}
+
+func TestUnit_GetNewslettersStatistics(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v3/newsletters/statistics" || q.Get("query[newsletterId]") != "n1,n2" || q.Get("query[campaignId]") != "V" || q.Get("query[groupBy]") != "total" {
+			errorHandler(http.StatusBadRequest, ErrorInvalidParameterFormat)(w, r)
+			return
+		}
+		fmt.Fprint(w, testStatistics)
+	}), 5*time.Second)
+	defer ts.Close()
+
+	ret, err := c.GetNewslettersStatistics(context.Background(), StatisticsFilter{GroupBy: GroupByTotal, NewsletterIDs: []string{"n1", "n2"}, CampaignIDs: []string{"V"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := MessageStatistics{
+		TimeInterval: TimeInterval{
+			From: Timestamp{time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)},
+			To:   Timestamp{time.Date(2018, 1, 2, 23, 59, 59, 0, time.UTC)},
+		},
+		Sent: 100, TotalOpened: 30, UniqueOpened: 20, TotalClicked: 6, UniqueClicked: 5, Unsubscribed: 3,
+	}
+	if len(ret) != 2 || !reflect.DeepEqual(expected, ret[1]) {
+		t.Fatalf("Actual statistics (%#v) did not match expected (%#v)", ret, expected)
+	}
+}
+
+func TestUnit_SummarizeStatistics(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testStatistics)
+	}), 5*time.Second)
+	defer ts.Close()
+	rows, err := c.GetNewsletterStatistics(context.Background(), "n1", StatisticsFilter{})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := StatisticsSummary{
+		From:          time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:            time.Date(2018, 1, 2, 23, 59, 59, 0, time.UTC),
+		Sent:          200,
+		TotalOpened:   90,
+		UniqueOpened:  60,
+		TotalClicked:  18,
+		UniqueClicked: 15,
+		Unsubscribed:  3,
+		Bounced:       2,
+		Complaints:    1,
+		OpenRate:      0.3,
+		ClickRate:     0.075,
+		BounceRate:    0.01,
+		ComplaintRate: 0.005,
+	}
+	if ret := SummarizeStatistics(rows); !reflect.DeepEqual(expected, ret) {
+		t.Fatalf("Actual summary (%#v) did not match expected (%#v)", ret, expected)
+	}
+	if ret := SummarizeStatistics(nil); !reflect.DeepEqual(StatisticsSummary{}, ret) {
+		t.Fatalf("Expected an empty summary, got %#v", ret)
+	}
+}
@@ -0,0 +1,188 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+type contactTagsRequest struct {
+	Tags []Tag `json:"tags"`
+}
+
+func (g *getResponseClient) GetTags(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, page int32, perPage int32) ([]Tag, glitch.DataError) {
+	result := make([]Tag, 0)
+	err := g.do(ctx, http.MethodGet, "/v3/tags", listQuery(queryHash, fields, sortHash, page, perPage), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) IterateTags(ctx context.Context, queryHash map[string]string, fields []string, sortHash map[string]string, perPage int32) *TagIterator {
+	it := &TagIterator{}
+	it.p = newPager(ctx, perPage, func(ctx context.Context, page int32, perPage int32) (int, http.Header, glitch.DataError) {
+		it.buf = make([]Tag, 0)
+		h, err := g.doWithHeaders(ctx, http.MethodGet, "/v3/tags", listQuery(queryHash, fields, sortHash, page, perPage), nil, &it.buf)
+		return len(it.buf), h, err
+	})
+	return it
+}
+
+func (g *getResponseClient) GetTag(ctx context.Context, ID string, fields []string) (Tag, glitch.DataError) {
+	result := Tag{}
+	err := g.do(ctx, http.MethodGet, fmt.Sprintf("/v3/tags/%s", ID), fieldsQuery(fields), nil, &result)
+	return result, err
+}
+
+func (g *getResponseClient) CreateTag(ctx context.Context, tag Tag) (Tag, glitch.DataError) {
+	result := Tag{}
+	err := g.do(ctx, http.MethodPost, "/v3/tags", nil, tag, &result)
+	return result, err
+}
+
+func (g *getResponseClient) UpdateTag(ctx context.Context, ID string, newData Tag) (Tag, glitch.DataError) {
+	result := Tag{}
+	err := g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/tags/%s", ID), nil, newData, &result)
+	return result, err
+}
+
+func (g *getResponseClient) DeleteTag(ctx context.Context, ID string) glitch.DataError {
+	return g.do(ctx, http.MethodDelete, fmt.Sprintf("/v3/tags/%s", ID), nil, nil, nil)
+}
+
+func (g *getResponseClient) ResolveTags(ctx context.Context, names []string, create bool) ([]Tag, glitch.DataError) {
+	byName := map[string]Tag{}
+	it := g.IterateTags(ctx, nil, nil, nil, 1000)
+	for it.Next() {
+		if it.Tag().Name != nil {
+			byName[*it.Tag().Name] = it.Tag()
+		}
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	ret := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tag, ok := byName[name]
+		if !ok && !create {
+			return nil, &APIError{ErrorResponse: ErrorResponse{
+				HTTPStatus: http.StatusNotFound,
+				ErrorCode:  ErrorResourceNotFound,
+				Message:    fmt.Sprintf("Tag %q not found", name),
+			}}
+		}
+		if !ok {
+			var err glitch.DataError
+			tag, err = g.createTagByName(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			byName[name] = tag
+		}
+		ret = append(ret, tag)
+	}
+	return ret, nil
+}
+
+// createTagByName creates the tag, returning the existing one if someone else created it first
+func (g *getResponseClient) createTagByName(ctx context.Context, name string) (Tag, glitch.DataError) {
+	tag, err := g.CreateTag(ctx, Tag{Name: &name})
+	if !errors.Is(err, ErrResourceAlreadyExists) {
+		return tag, err
+	}
+
+	it := g.IterateTags(ctx, map[string]string{"name": name}, nil, nil, DefaultPerPage)
+	found, lookupErr := findExact(it, func() bool { return it.Tag().Name != nil && *it.Tag().Name == name })
+	if lookupErr != nil {
+		return Tag{}, lookupErr
+	}
+	if found {
+		return it.Tag(), nil
+	}
+	return Tag{}, err
+}
+
+func (g *getResponseClient) AddContactTags(ctx context.Context, contactID string, tags []Tag) glitch.DataError {
+	bodyObj := contactTagsRequest{Tags: tagReferences(tags)}
+	return g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/contacts/%s/tags", contactID), nil, bodyObj, nil)
+}
+
+func (g *getResponseClient) AddContactTagsByName(ctx context.Context, contactID string, names []string) glitch.DataError {
+	tags, err := g.ResolveTags(ctx, names, true)
+	if err != nil {
+		return err
+	}
+	return g.AddContactTags(ctx, contactID, tags)
+}
+
+func (g *getResponseClient) RemoveContactTags(ctx context.Context, contactID string, tags []Tag) glitch.DataError {
+	// GR has no endpoint to remove tags so the contact is updated with the tags it should keep
+	contact, err := g.GetContact(ctx, contactID, []string{"tags"})
+	if err != nil {
+		return err
+	}
+
+	remove := map[string]bool{}
+	for _, t := range tags {
+		remove[t.TagID] = true
+	}
+	keep := make([]Tag, 0, len(contact.Tags))
+	for _, t := range contact.Tags {
+		if !remove[t.TagID] {
+			keep = append(keep, t)
+		}
+	}
+	if len(keep) == len(contact.Tags) {
+		return nil
+	}
+
+	bodyObj := contactTagsRequest{Tags: tagReferences(keep)}
+	return g.do(ctx, http.MethodPost, fmt.Sprintf("/v3/contacts/%s", contactID), nil, bodyObj, nil)
+}
+
+// tagReferences strips tags down to their IDs, which is all GR accepts when tagging contacts
+func tagReferences(tags []Tag) []Tag {
+	ret := make([]Tag, 0, len(tags))
+	for _, t := range tags {
+		ret = append(ret, Tag{TagID: t.TagID})
+	}
+	return ret
+}
+
+// TagIterator walks the pages of a tag listing.  Call Next until it returns false and then check Err.
+type TagIterator struct {
+	p   *pager
+	buf []Tag
+	cur Tag
+}
+
+// Next advances to the next tag, fetching the next page when needed
+func (it *TagIterator) Next() bool {
+	if !it.p.alive() {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if !it.p.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Tag returns the tag the iterator is positioned at
+func (it *TagIterator) Tag() Tag {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *TagIterator) Err() glitch.DataError {
+	return it.p.err
+}
+
+// Pagination returns the paging headers of the most recently fetched page
+func (it *TagIterator) Pagination() Pagination {
+	return it.p.pagination
+}
@@ -0,0 +1,274 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_GetTags(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		handler          http.HandlerFunc
+		expectedErrCode  *string
+		expectedResponse []Tag
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "base path",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/tags" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `[{"tagId": "vBd5", "name": "vip", "color": ""}]`)
+			}),
+			expectedResponse: []Tag{Tag{TagID: "vBd5", Name: makeStringPtr("vip"), Color: makeStringPtr("")}},
+		},
+		testcase{
+			name:            "unmarshal error",
+			handler:         http.HandlerFunc(undecodableHandler),
+			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
+		},
+		testcase{
+			name:            "error response",
+			handler:         errorHandler(http.StatusUnauthorized, ErrorAuthenticationFailure),
+			expectedErrCode: makeStringPtr("1014"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(tc.handler, 5*time.Second)
+			defer ts.Close()
+			ret, err := c.GetTags(context.Background(), nil, nil, nil, 1, 10)
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_TagCRUD(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		call             func(c Client) (Tag, error)
+		expectedMethod   string
+		expectedPath     string
+		expectedResponse Tag
+	}
+
+	ctx := context.Background()
+	testcases := []testcase{
+		testcase{
+			name:             "get",
+			call:             func(c Client) (Tag, error) { return c.GetTag(ctx, "vBd5", nil) },
+			expectedMethod:   http.MethodGet,
+			expectedPath:     "/v3/tags/vBd5",
+			expectedResponse: Tag{TagID: "vBd5", Name: makeStringPtr("vip")},
+		},
+		testcase{
+			name:             "create",
+			call:             func(c Client) (Tag, error) { return c.CreateTag(ctx, Tag{Name: makeStringPtr("vip")}) },
+			expectedMethod:   http.MethodPost,
+			expectedPath:     "/v3/tags",
+			expectedResponse: Tag{TagID: "vBd5", Name: makeStringPtr("vip")},
+		},
+		testcase{
+			name:             "update",
+			call:             func(c Client) (Tag, error) { return c.UpdateTag(ctx, "vBd5", Tag{Name: makeStringPtr("vip")}) },
+			expectedMethod:   http.MethodPost,
+			expectedPath:     "/v3/tags/vBd5",
+			expectedResponse: Tag{TagID: "vBd5", Name: makeStringPtr("vip")},
+		},
+		testcase{
+			name: "delete",
+			call: func(c Client) (Tag, error) {
+				if err := c.DeleteTag(ctx, "vBd5"); err != nil {
+					return Tag{}, err
+				}
+				return Tag{}, nil
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/v3/tags/vBd5",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tc.expectedMethod || r.URL.Path != tc.expectedPath {
+					errorHandler(http.StatusNotFound, ErrorResourceNotFound)(w, r)
+					return
+				}
+				if r.Method != http.MethodDelete {
+					fmt.Fprint(w, `{"tagId": "vBd5", "name": "vip"}`)
+				}
+			}), 5*time.Second)
+			defer ts.Close()
+			ret, err := tc.call(c)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestUnit_ResolveTags(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		names            []string
+		create           bool
+		createStatus     int
+		expectedCreates  []string
+		expectedErrCode  *string
+		expectedResponse []Tag
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:             "existing tags",
+			names:            []string{"vip", "trial"},
+			expectedCreates:  []string{},
+			expectedResponse: []Tag{Tag{TagID: "t1", Name: makeStringPtr("vip")}, Tag{TagID: "t2", Name: makeStringPtr("trial")}},
+		},
+		testcase{
+			name:            "missing tag",
+			names:           []string{"vip", "churned"},
+			expectedCreates: []string{},
+			expectedErrCode: makeStringPtr("1013"),
+		},
+		testcase{
+			name:             "missing tag is created",
+			names:            []string{"churned", "vip"},
+			create:           true,
+			createStatus:     http.StatusCreated,
+			expectedCreates:  []string{"churned"},
+			expectedResponse: []Tag{Tag{TagID: "t3", Name: makeStringPtr("churned")}, Tag{TagID: "t1", Name: makeStringPtr("vip")}},
+		},
+		testcase{
+			name:             "tag created concurrently",
+			names:            []string{"churned"},
+			create:           true,
+			createStatus:     http.StatusConflict,
+			expectedCreates:  []string{"churned"},
+			expectedResponse: []Tag{Tag{TagID: "t9", Name: makeStringPtr("churned")}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			creates := []string{}
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost:
+					tag := Tag{}
+					json.NewDecoder(r.Body).Decode(&tag)
+					creates = append(creates, *tag.Name)
+					if tc.createStatus == http.StatusConflict {
+						errorHandler(http.StatusConflict, ErrorResourceAlreadyExists)(w, r)
+						return
+					}
+					w.WriteHeader(tc.createStatus)
+					fmt.Fprintf(w, `{"tagId": "t3", "name": "%s"}`, *tag.Name)
+				case r.URL.Query().Get("query[name]") == "churned":
+					fmt.Fprint(w, `[{"tagId": "t8", "name": "churned_2019"}, {"tagId": "t9", "name": "churned"}]`)
+				default:
+					fmt.Fprint(w, `[{"tagId": "t1", "name": "vip"}, {"tagId": "t2", "name": "trial"}]`)
+				}
+			}), 5*time.Second)
+			defer ts.Close()
+
+			ret, err := c.ResolveTags(context.Background(), tc.names, tc.create)
+			if !reflect.DeepEqual(tc.expectedCreates, creates) {
+				t.Fatalf("Actual creates (%#v) did not match expected (%#v)", creates, tc.expectedCreates)
+			}
+			if checkDataError(t, err, tc.expectedErrCode) && !reflect.DeepEqual(tc.expectedResponse, ret) {
+				t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+			}
+			if err != nil && !errors.Is(err, ErrResourceNotFound) {
+				t.Fatalf("Error (%#v) does not match ErrResourceNotFound", err)
+			}
+		})
+	}
+}
+
+func TestUnit_ContactTags(t *testing.T) {
+	var posted map[string]interface{}
+	var postedPath string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/tags":
+			fmt.Fprint(w, `[{"tagId": "t1", "name": "vip"}]`)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"contactId": "abc", "tags": [{"tagId": "t1", "name": "vip"}, {"tagId": "t2", "name": "trial"}]}`)
+		default:
+			postedPath = r.URL.Path
+			posted = map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&posted)
+			fmt.Fprint(w, `{}`)
+		}
+	}), 5*time.Second)
+	defer ts.Close()
+
+	type testcase struct {
+		name         string
+		call         func() error
+		expectedPath string
+		expectedBody map[string]interface{}
+	}
+
+	ctx := context.Background()
+	testcases := []testcase{
+		testcase{
+			name: "add",
+			call: func() error {
+				return c.AddContactTags(ctx, "abc", []Tag{Tag{TagID: "t3", Name: makeStringPtr("ignored")}})
+			},
+			expectedPath: "/v3/contacts/abc/tags",
+			expectedBody: map[string]interface{}{"tags": []interface{}{map[string]interface{}{"tagId": "t3"}}},
+		},
+		testcase{
+			name:         "add by name",
+			call:         func() error { return c.AddContactTagsByName(ctx, "abc", []string{"vip"}) },
+			expectedPath: "/v3/contacts/abc/tags",
+			expectedBody: map[string]interface{}{"tags": []interface{}{map[string]interface{}{"tagId": "t1"}}},
+		},
+		testcase{
+			name:         "remove",
+			call:         func() error { return c.RemoveContactTags(ctx, "abc", []Tag{Tag{TagID: "t1"}}) },
+			expectedPath: "/v3/contacts/abc",
+			expectedBody: map[string]interface{}{"tags": []interface{}{map[string]interface{}{"tagId": "t2"}}},
+		},
+		testcase{
+			name:         "remove all",
+			call:         func() error { return c.RemoveContactTags(ctx, "abc", []Tag{Tag{TagID: "t1"}, Tag{TagID: "t2"}}) },
+			expectedPath: "/v3/contacts/abc",
+			expectedBody: map[string]interface{}{"tags": []interface{}{}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			posted, postedPath = nil, ""
+			if err := tc.call(); err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if postedPath != tc.expectedPath || !reflect.DeepEqual(tc.expectedBody, posted) {
+				t.Fatalf("Actual post (%s %#v) did not match expected (%s %#v)", postedPath, posted, tc.expectedPath, tc.expectedBody)
+			}
+		})
+	}
+}
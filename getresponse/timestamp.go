@@ -0,0 +1,71 @@
+package getresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimestampFormat is the layout GR uses for most timestamps, e.g. 2018-01-15T10:00:00+0000
+const TimestampFormat = "2006-01-02T15:04:05-0700"
+
+// timestampLayouts are the layouts GR uses across resources, tried in order
+var timestampLayouts = []string{
+	TimestampFormat,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	CustomFieldDatetimeFormat,
+	CustomFieldDateFormat,
+}
+
+// Timestamp is a time returned by GR.  It accepts every layout GR uses and marshals back to TimestampFormat.
+// Parsed timestamps are in UTC; those without an offset are taken to be UTC.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns a pointer to a Timestamp of t, for setting optional fields
+func NewTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{Time: t}
+}
+
+// ParseTimestamp parses s using the layouts GR uses, returning it in UTC
+func ParseTimestamp(s string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t.UTC()}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("unrecognized timestamp %q", s)
+}
+
+// MarshalJSON writes the timestamp in TimestampFormat, or null for the zero time
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(TimestampFormat))
+}
+
+// UnmarshalJSON reads any of the layouts GR uses.  null and "" leave the zero time.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*t = Timestamp{}
+		return nil
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
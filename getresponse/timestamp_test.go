@@ -0,0 +1,97 @@
+package getresponse
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUnit_TimestampUnmarshal(t *testing.T) {
+
+	type testcase struct {
+		name          string
+		json          string
+		expectedError bool
+		expectedTime  time.Time
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:         "GR format",
+			json:         `"2018-01-15T10:00:00+0100"`,
+			expectedTime: time.Date(2018, 1, 15, 9, 0, 0, 0, time.UTC),
+		},
+		testcase{
+			name:         "RFC 3339",
+			json:         `"2018-01-15T10:00:00.5+01:00"`,
+			expectedTime: time.Date(2018, 1, 15, 9, 0, 0, 500000000, time.UTC),
+		},
+		testcase{
+			name:         "without offset",
+			json:         `"2018-01-15T10:00:00"`,
+			expectedTime: time.Date(2018, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+		testcase{
+			name:         "datetime",
+			json:         `"2018-01-15 10:00:00"`,
+			expectedTime: time.Date(2018, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+		testcase{
+			name:         "date",
+			json:         `"2018-01-15"`,
+			expectedTime: time.Date(2018, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+		testcase{
+			name: "null",
+			json: `null`,
+		},
+		testcase{
+			name: "empty",
+			json: `""`,
+		},
+		testcase{
+			name:          "unrecognized",
+			json:          `"15/01/2018"`,
+			expectedError: true,
+		},
+		testcase{
+			name:          "not a string",
+			json:          `1516010400`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := Timestamp{}
+			err := json.Unmarshal([]byte(tc.json), &ts)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("Unexpected error (%v)", err)
+			}
+			if !ts.Equal(tc.expectedTime) {
+				t.Fatalf("Actual time (%v) did not match expected (%v)", ts.Time, tc.expectedTime)
+			}
+		})
+	}
+}
+
+func TestUnit_TimestampMarshal(t *testing.T) {
+	c := Contact{CreatedOn: NewTimestamp(time.Date(2018, 1, 15, 10, 0, 0, 0, time.FixedZone("", 3600)))}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if string(b) != `{"createdOn":"2018-01-15T10:00:00+0100"}` {
+		t.Fatalf("Unexpected JSON %s", b)
+	}
+
+	ret := Contact{}
+	if err := json.Unmarshal(b, &ret); err != nil || !ret.CreatedOn.Equal(c.CreatedOn.Time) {
+		t.Fatalf("Timestamp did not round trip (%v, %v)", ret.CreatedOn, err)
+	}
+
+	b, _ = json.Marshal(Timestamp{})
+	if string(b) != "null" {
+		t.Fatalf("Expected the zero time to marshal to null, got %s", b)
+	}
+}
@@ -6,6 +6,7 @@ type createContactRequest struct {
 	DayOfCycle        *int32        `json:"dayOfCycle,omitempty"`
 	Campaign          Campaign      `json:"campaign"` // required
 	CustomFieldValues []CustomField `json:"customFieldValues,omitempty"`
+	Tags              []Tag         `json:"tags,omitempty"`
 	IPAddress         *string       `json:"ipAddress,omitempty"`
 }
 
@@ -13,18 +14,122 @@ type updateCustomFieldRequest struct {
 	VustomFieldValues []CustomField `json:"customFieldValues"`
 }
 
-// Campaign holds the representation of a campaign
+// Campaign holds the representation of a campaign (a list in the GR UI)
 type Campaign struct {
-	CampaignID string  `json:"campaignId"` // required
-	Name       string  `json:"name,omitempty"`
-	Href       *string `json:"href,omitempty"`
+	CampaignID                string                     `json:"campaignId,omitempty"` // required when referencing a campaign
+	Name                      string                     `json:"name,omitempty"`
+	Href                      *string                    `json:"href,omitempty"`
+	TechName                  *string                    `json:"techName,omitempty"`
+	Description               *string                    `json:"description,omitempty"`
+	LanguageCode              *string                    `json:"languageCode,omitempty"`
+	IsDefault                 *string                    `json:"isDefault,omitempty"` // GR sends "true" or "false"
+	CreatedOn                 *Timestamp                 `json:"createdOn,omitempty"`
+	OptinTypes                *CampaignOptinTypes        `json:"optinTypes,omitempty"`
+	SubscriptionNotifications *SubscriptionNotifications `json:"subscriptionNotifications,omitempty"`
+	Postal                    *CampaignPostal            `json:"postal,omitempty"`
+	Confirmation              *CampaignConfirmation      `json:"confirmation,omitempty"`
+	Profile                   *CampaignProfile           `json:"profile,omitempty"`
+}
+
+// Optin types
+const (
+	OptinSingle = "single"
+	OptinDouble = "double"
+)
+
+// CampaignOptinTypes holds the optin type (OptinSingle or OptinDouble) used for each subscription method
+type CampaignOptinTypes struct {
+	Email   *string `json:"email,omitempty"`
+	API     *string `json:"api,omitempty"`
+	Import  *string `json:"import,omitempty"`
+	Webform *string `json:"webform,omitempty"`
+}
+
+// SubscriptionNotifications controls who is emailed when someone subscribes to a campaign
+type SubscriptionNotifications struct {
+	Status     *string              `json:"status,omitempty"` // "enabled" or "disabled"
+	Recipients []FromFieldReference `json:"recipients,omitempty"`
+}
+
+// FromFieldReference points at a from field (a verified sender address)
+type FromFieldReference struct {
+	FromFieldID string  `json:"fromFieldId"`
+	Href        *string `json:"href,omitempty"`
+}
+
+// CampaignPostal holds the postal address added to messages sent from a campaign
+type CampaignPostal struct {
+	AddPostalToMessages *string `json:"addPostalToMessages,omitempty"` // GR sends "true" or "false"
+	City                *string `json:"city,omitempty"`
+	CompanyName         *string `json:"companyName,omitempty"`
+	Design              *string `json:"design,omitempty"`
+	State               *string `json:"state,omitempty"`
+	Street              *string `json:"street,omitempty"`
+	ZipCode             *string `json:"zipCode,omitempty"`
+	Country             *string `json:"country,omitempty"`
+}
+
+// CampaignConfirmation holds the double optin confirmation settings of a campaign
+type CampaignConfirmation struct {
+	FromField                         *FromFieldReference `json:"fromField,omitempty"`
+	ReplyTo                           *FromFieldReference `json:"replyTo,omitempty"`
+	RedirectType                      *string             `json:"redirectType,omitempty"` // "hosted" or "customUrl"
+	RedirectURL                       *string             `json:"redirectUrl,omitempty"`
+	MimeType                          *string             `json:"mimeType,omitempty"`
+	SubscriptionConfirmationBodyID    *string             `json:"subscriptionConfirmationBodyId,omitempty"`
+	SubscriptionConfirmationSubjectID *string             `json:"subscriptionConfirmationSubjectId,omitempty"`
+}
+
+// CampaignProfile holds the public profile of a campaign
+type CampaignProfile struct {
+	IndustryTagID *string `json:"industryTagId,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Logo          *string `json:"logo,omitempty"`
+	LogoLinkURL   *string `json:"logoLinkUrl,omitempty"`
+	Title         *string `json:"title,omitempty"`
 }
 
-// CustomField holds key value sets
+// CustomField holds key value sets.  Use CustomFieldValue or NamedCustomFieldValue to have the client encode typed
+// values against the custom field definitions.
 type CustomField struct {
 	CustomFieldID string   `json:"customFieldId"`
 	Value         []string `json:"value"`
 	Href          *string  `json:"href,omitempty"`
+
+	name  string        // set by NamedCustomFieldValue, resolved to CustomFieldID before sending
+	typed []interface{} // set by CustomFieldValue and NamedCustomFieldValue, encoded into Value before sending
+}
+
+// Custom field types
+const (
+	CustomFieldTypeText         = "text"
+	CustomFieldTypeTextarea     = "textarea"
+	CustomFieldTypeRadio        = "radio"
+	CustomFieldTypeCheckbox     = "checkbox"
+	CustomFieldTypeSingleSelect = "single_select"
+	CustomFieldTypeMultiSelect  = "multi_select"
+	CustomFieldTypeNumber       = "number"
+	CustomFieldTypeDate         = "date"
+	CustomFieldTypeDatetime     = "datetime"
+	CustomFieldTypeCountry      = "country"
+	CustomFieldTypeCurrency     = "currency"
+	CustomFieldTypePhone        = "phone"
+	CustomFieldTypeGender       = "gender"
+	CustomFieldTypeIP           = "ip"
+	CustomFieldTypeURL          = "url"
+)
+
+// CustomFieldDefinition describes a custom field of the account
+type CustomFieldDefinition struct {
+	CustomFieldID string   `json:"customFieldId,omitempty"`
+	Href          *string  `json:"href,omitempty"`
+	Name          string   `json:"name,omitempty"` // required on create
+	Type          string   `json:"type,omitempty"` // one of the CustomFieldType* constants, required on create
+	FieldType     *string  `json:"fieldType,omitempty"`
+	Format        *string  `json:"format,omitempty"`
+	ValueType     *string  `json:"valueType,omitempty"`
+	Hidden        *string  `json:"hidden,omitempty"` // GR sends "true" or "false", required on create
+	Values        []string `json:"values,omitempty"` // the options of choice fields
 }
 
 // Geolocation holds geo data on contacts
@@ -39,8 +144,13 @@ type Geolocation struct {
 	City          *string `json:"city,omitempty"`
 }
 
+// Tag holds a tag of the account.  Only TagID is needed to tag a contact.
 type Tag struct {
-	TagID string `json:"tagId"`
+	TagID     string     `json:"tagId,omitempty"`
+	Href      *string    `json:"href,omitempty"`
+	Name      *string    `json:"name,omitempty"` // required on create
+	Color     *string    `json:"color,omitempty"`
+	CreatedAt *Timestamp `json:"createdAt,omitempty"`
 }
 
 // Contact represents a GR contact
@@ -52,8 +162,8 @@ type Contact struct {
 	Note              *string       `json:"note,omitempty"`
 	DayOfCycle        *int32        `json:"dayOfCycle,omitempty"`
 	Origin            *string       `json:"origin,omitempty"`
-	CreatedOn         *string       `json:"createdOn,omitempty"` // there doesn't seem to be any docs on what timezone these times are in so I'm leaving them as strings (timeZone below is the user's timezone)
-	ChangedOn         *string       `json:"changedOn,omitempty"`
+	CreatedOn         *Timestamp    `json:"createdOn,omitempty"` // timeZone below is the user's timezone, not the timezone of these
+	ChangedOn         *Timestamp    `json:"changedOn,omitempty"`
 	Campaign          *Campaign     `json:"campaign,omitempty"`
 	Geolocation       *Geolocation  `json:"geolocation,omitempty"`
 	Tags              []Tag         `json:"tags,omitempty"`
@@ -64,6 +174,194 @@ type Contact struct {
 	Scoring           *int64        `json:"scoring,omitempty"`
 }
 
+// ContactActivity is something a contact did or had done to them, e.g. opening a message
+type ContactActivity struct {
+	Activity   string            `json:"activity"` // one of the Activity* constants
+	Subject    *string           `json:"subject,omitempty"`
+	CreatedOn  *Timestamp        `json:"createdOn,omitempty"`
+	PreviewURL *string           `json:"previewUrl,omitempty"`
+	Resource   *ActivityResource `json:"resource,omitempty"`
+}
+
+// ActivityResource is the message or goal an activity relates to
+type ActivityResource struct {
+	ResourceID   string  `json:"resourceId"`
+	ResourceType *string `json:"resourceType,omitempty"` // e.g. "newsletters", "autoresponders", "goals"
+	Href         *string `json:"href,omitempty"`
+}
+
+// SearchContact is a saved search (a segment in the GR UI)
+type SearchContact struct {
+	SearchContactID      string                  `json:"searchContactId,omitempty"`
+	Href                 *string                 `json:"href,omitempty"`
+	Name                 *string                 `json:"name,omitempty"` // required on create
+	CreatedOn            *Timestamp              `json:"createdOn,omitempty"`
+	SubscribersType      []string                `json:"subscribersType,omitempty"`      // SubscribersType* constants
+	SectionLogicOperator *string                 `json:"sectionLogicOperator,omitempty"` // LogicAnd or LogicOr
+	Section              []SearchContactsSection `json:"section,omitempty"`
+}
+
+// SearchContactsConditions is the condition tree of an ad hoc search, i.e. a SearchContact that isn't saved
+type SearchContactsConditions struct {
+	SubscribersType      []string                `json:"subscribersType"`
+	SectionLogicOperator string                  `json:"sectionLogicOperator"`
+	Section              []SearchContactsSection `json:"section"`
+}
+
+// SearchContactsSection combines conditions on the contacts of some campaigns
+type SearchContactsSection struct {
+	CampaignIDsList  []string                  `json:"campaignIdsList"`
+	LogicOperator    string                    `json:"logicOperator"`              // LogicAnd or LogicOr
+	SubscriberCycle  []string                  `json:"subscriberCycle,omitempty"`  // e.g. "receiving_autoresponder"
+	SubscriptionDate *string                   `json:"subscriptionDate,omitempty"` // e.g. "all_time"
+	Conditions       []SearchContactsCondition `json:"conditions"`
+}
+
+// SearchContactsCondition is a single comparison.  The Condition helpers build the common ones.
+type SearchContactsCondition struct {
+	ConditionType string  `json:"conditionType"`   // ConditionType* constants
+	Scope         *string `json:"scope,omitempty"` // the custom field ID of custom conditions
+	OperatorType  *string `json:"operatorType,omitempty"`
+	Operator      string  `json:"operator"`
+	Value         *string `json:"value,omitempty"`
+	DateFrom      *string `json:"dateFrom,omitempty"`
+	DateTo        *string `json:"dateTo,omitempty"`
+}
+
+type createImportRequest struct {
+	Campaign     Campaign   `json:"campaign"`
+	FieldMapping []string   `json:"fieldMapping"`
+	Contacts     [][]string `json:"contacts"`
+}
+
+// Import is a bulk import of contacts into a campaign
+type Import struct {
+	ImportID   string            `json:"importId,omitempty"`
+	Href       *string           `json:"href,omitempty"`
+	Campaign   *Campaign         `json:"campaign,omitempty"`
+	Status     *string           `json:"status,omitempty"` // ImportStatus* constants
+	CreatedOn  *Timestamp        `json:"createdOn,omitempty"`
+	FinishedOn *Timestamp        `json:"finishedOn,omitempty"`
+	Statistics *ImportStatistics `json:"statistics,omitempty"`
+}
+
+// ImportStatistics counts what GR did with the rows of an import
+type ImportStatistics struct {
+	Uploaded    int64 `json:"uploaded"`
+	Invalid     int64 `json:"invalid"`
+	Updated     int64 `json:"updated"`
+	AddedToList int64 `json:"addedToList"`
+}
+
+// Newsletter is a message sent once to the contacts of campaigns, segments or a list of contacts
+type Newsletter struct {
+	NewsletterID string                  `json:"newsletterId,omitempty"`
+	Href         *string                 `json:"href,omitempty"`
+	Name         *string                 `json:"name,omitempty"`
+	Type         *string                 `json:"type,omitempty"` // NewsletterType* constants
+	Status       *string                 `json:"status,omitempty"`
+	Editor       *string                 `json:"editor,omitempty"`
+	Subject      *string                 `json:"subject,omitempty"`   // required on create
+	FromField    *FromFieldReference     `json:"fromField,omitempty"` // required on create
+	ReplyTo      *FromFieldReference     `json:"replyTo,omitempty"`
+	Campaign     *Campaign               `json:"campaign,omitempty"` // required on create
+	Content      *NewsletterContent      `json:"content,omitempty"`  // required on create
+	Flags        []string                `json:"flags,omitempty"`    // e.g. "openrate", "clicktrack", "google_analytics"
+	SendOn       *Timestamp              `json:"sendOn,omitempty"`   // schedules the newsletter, unset sends it now
+	CreatedOn    *Timestamp              `json:"createdOn,omitempty"`
+	SendSettings *NewsletterSendSettings `json:"sendSettings,omitempty"` // required on create
+	SendMetrics  *NewsletterSendMetrics  `json:"sendMetrics,omitempty"`
+}
+
+// NewsletterContent is the body of a message
+type NewsletterContent struct {
+	HTML  *string `json:"html,omitempty"`
+	Plain *string `json:"plain,omitempty"`
+}
+
+// NewsletterSendSettings selects who a newsletter is sent to
+type NewsletterSendSettings struct {
+	SelectedCampaigns    []string `json:"selectedCampaigns,omitempty"`
+	SelectedSegments     []string `json:"selectedSegments,omitempty"`
+	SelectedSuppressions []string `json:"selectedSuppressions,omitempty"`
+	ExcludedCampaigns    []string `json:"excludedCampaigns,omitempty"`
+	ExcludedSegments     []string `json:"excludedSegments,omitempty"`
+	SelectedContacts     []string `json:"selectedContacts,omitempty"`
+	TimeTravel           *string  `json:"timeTravel,omitempty"`    // "true" sends at SendOn in each contact's timezone
+	PerfectTiming        *string  `json:"perfectTiming,omitempty"` // "true" sends when each contact usually reads
+}
+
+// NewsletterSendMetrics is the sending progress of a newsletter
+type NewsletterSendMetrics struct {
+	Status *string `json:"status,omitempty"`
+	Sent   *string `json:"sent,omitempty"`
+	Total  *string `json:"total,omitempty"`
+}
+
+type sendNewsletterDraftRequest struct {
+	MessageID    string                 `json:"messageId"`
+	SendOn       *Timestamp             `json:"sendOn,omitempty"`
+	SendSettings NewsletterSendSettings `json:"sendSettings"`
+}
+
+// MessageStatistics is a row of the statistics of messages, covering TimeInterval
+type MessageStatistics struct {
+	TimeInterval  TimeInterval `json:"timeInterval"`
+	Sent          int64        `json:"sent"`
+	TotalOpened   int64        `json:"totalOpened"`
+	UniqueOpened  int64        `json:"uniqueOpened"`
+	TotalClicked  int64        `json:"totalClicked"`
+	UniqueClicked int64        `json:"uniqueClicked"`
+	Goals         int64        `json:"goals"`
+	UniqueGoals   int64        `json:"uniqueGoals"`
+	Forwarded     int64        `json:"forwarded"`
+	Unsubscribed  int64        `json:"unsubscribed"`
+	Bounced       int64        `json:"bounced"`
+	Complaints    int64        `json:"complaints"`
+}
+
+// Autoresponder is a message sent to each contact of a campaign on a day of their cycle or after they do something
+type Autoresponder struct {
+	AutoresponderID string                        `json:"autoresponderId,omitempty"`
+	Href            *string                       `json:"href,omitempty"`
+	Name            *string                       `json:"name,omitempty"`
+	Subject         *string                       `json:"subject,omitempty"`    // required on create
+	CampaignID      *string                       `json:"campaignId,omitempty"` // required on create
+	Status          *string                       `json:"status,omitempty"`     // "enabled" or "disabled"
+	Editor          *string                       `json:"editor,omitempty"`
+	FromField       *FromFieldReference           `json:"fromField,omitempty"` // required on create
+	ReplyTo         *FromFieldReference           `json:"replyTo,omitempty"`
+	Content         *NewsletterContent            `json:"content,omitempty"` // required on create
+	Flags           []string                      `json:"flags,omitempty"`
+	TriggerSettings *AutoresponderTriggerSettings `json:"triggerSettings,omitempty"` // required on create
+	CreatedOn       *Timestamp                    `json:"createdOn,omitempty"`
+}
+
+// AutoresponderTriggerSettings decide when an autoresponder is sent
+type AutoresponderTriggerSettings struct {
+	Type              string   `json:"type"`                        // AutoresponderTrigger* constants
+	DayOfCycle        *int32   `json:"dayOfCycle,omitempty"`        // required by AutoresponderTriggerDay
+	Action            *string  `json:"action,omitempty"`            // AutoresponderAction* constants, required by AutoresponderTriggerAction
+	ActionMessageID   *string  `json:"actionMessageId,omitempty"`   // message opened or clicked by open and click actions
+	SelectedCampaigns []string `json:"selectedCampaigns,omitempty"` // campaigns whose contacts trigger it
+	DelayInHours      *int32   `json:"delayInHours,omitempty"`      // wait after the trigger
+	SendAtHour        *int32   `json:"sendAtHour,omitempty"`        // hour of the day, 0-23, it is sent at
+	SelectedDays      []string `json:"selectedDays,omitempty"`      // days of the week it is sent on, see Weekdays
+	Recurrence        *string  `json:"recurrence,omitempty"`        // GR sends "true" or "false"
+}
+
+// FromField is a sender address of the account.  GR emails the address on creation and only sends from it once
+// the link in that email is followed.
+type FromField struct {
+	FromFieldID string     `json:"fromFieldId,omitempty"`
+	Href        *string    `json:"href,omitempty"`
+	Email       *string    `json:"email,omitempty"`     // required on create
+	Name        *string    `json:"name,omitempty"`      // required on create
+	IsActive    *string    `json:"isActive,omitempty"`  // GR sends "true" once the address is verified
+	IsDefault   *string    `json:"isDefault,omitempty"` // GR sends "true" or "false"
+	CreatedOn   *Timestamp `json:"createdOn,omitempty"`
+}
+
 /* ErrorResponse holds an API error
 example error:
 {
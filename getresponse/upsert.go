@@ -0,0 +1,116 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/healthimation/go-glitch/glitch"
+)
+
+func (g *getResponseClient) UpsertContact(ctx context.Context, email string, name *string, dayOfCycle *int32, campaignID string, customFields []CustomField, tags []Tag, ipAddress *string) (Contact, glitch.DataError) {
+	// encode typed values up front, merging only keeps the encoded values
+	customFields, err := g.encodeCustomFields(ctx, customFields)
+	if err != nil {
+		return Contact{}, err
+	}
+
+	existing, err := g.findContact(ctx, email, campaignID)
+	if err != nil {
+		return Contact{}, err
+	}
+	if existing != nil {
+		return g.mergeContact(ctx, *existing.ContactID, name, dayOfCycle, customFields, tags)
+	}
+
+	pending, err := g.createContactWithTags(ctx, email, name, dayOfCycle, campaignID, customFields, tags, ipAddress)
+	if errors.Is(err, ErrResourceAlreadyExists) {
+		// someone else added the contact since we looked, which GR may not have created yet either
+		other := &PendingContact{Email: email, CampaignID: campaignID, g: g}
+		existing, waitErr := other.Wait(ctx, g.pollPolicy)
+		if waitErr != nil && waitErr.Code() == ErrorTimeout {
+			return Contact{}, err
+		}
+		if waitErr != nil {
+			return Contact{}, waitErr
+		}
+		return g.mergeContact(ctx, *existing.ContactID, name, dayOfCycle, customFields, tags)
+	}
+	if err != nil {
+		return Contact{}, err
+	}
+
+	// contacts are created asynchronously
+	return pending.Wait(ctx, g.pollPolicy)
+}
+
+// findContact returns the contact with exactly this email in the campaign or nil if there isn't one
+func (g *getResponseClient) findContact(ctx context.Context, email string, campaignID string) (*Contact, glitch.DataError) {
+	it := g.IterateContacts(ctx, map[string]string{"email": email, "campaignId": campaignID}, nil, nil, DefaultPerPage, nil)
+	found, err := findExact(it, func() bool {
+		c := it.Contact()
+		return c.Email != nil && strings.EqualFold(*c.Email, email) && c.ContactID != nil
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+	c := it.Contact()
+	return &c, nil
+}
+
+// mergeContact updates the contact, keeping the custom fields and tags it has which are not in the update
+func (g *getResponseClient) mergeContact(ctx context.Context, ID string, name *string, dayOfCycle *int32, customFields []CustomField, tags []Tag) (Contact, glitch.DataError) {
+	existing, err := g.GetContact(ctx, ID, nil)
+	if err != nil {
+		return Contact{}, err
+	}
+
+	update := Contact{
+		Name:              name,
+		DayOfCycle:        dayOfCycle,
+		CustomFieldValues: mergeCustomFields(existing.CustomFieldValues, customFields),
+		Tags:              mergeTags(existing.Tags, tags),
+	}
+	if len(update.CustomFieldValues) == 0 {
+		update.CustomFieldValues = nil
+	}
+	if len(update.Tags) == 0 {
+		update.Tags = nil
+	}
+
+	return g.UpdateContact(ctx, ID, update)
+}
+
+// mergeCustomFields overrides the values in existing with the ones in update, adding any new fields
+func mergeCustomFields(existing []CustomField, update []CustomField) []CustomField {
+	ret := make([]CustomField, 0, len(existing)+len(update))
+	index := map[string]int{}
+	for _, cf := range existing {
+		index[cf.CustomFieldID] = len(ret)
+		ret = append(ret, CustomField{CustomFieldID: cf.CustomFieldID, Value: cf.Value})
+	}
+	for _, cf := range update {
+		if i, ok := index[cf.CustomFieldID]; ok {
+			ret[i].Value = cf.Value
+			continue
+		}
+		index[cf.CustomFieldID] = len(ret)
+		ret = append(ret, CustomField{CustomFieldID: cf.CustomFieldID, Value: cf.Value})
+	}
+	return ret
+}
+
+// mergeTags returns the union of both tag lists
+func mergeTags(existing []Tag, update []Tag) []Tag {
+	ret := make([]Tag, 0, len(existing)+len(update))
+	seen := map[string]bool{}
+	for _, list := range [][]Tag{existing, update} {
+		for _, t := range list {
+			if !seen[t.TagID] {
+				seen[t.TagID] = true
+				ret = append(ret, Tag{TagID: t.TagID})
+			}
+		}
+	}
+	return ret
+}
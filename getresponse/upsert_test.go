@@ -0,0 +1,156 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testPollPolicy = PollPolicy{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Timeout: 100 * time.Millisecond}
+
+// upsertHandler fakes the contact endpoints used by UpsertContact.  listed is returned by the listing calls in turn.
+func upsertHandler(listed []string, createStatus int, updates *[]Contact, creates *int) http.HandlerFunc {
+	listCalls := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/contacts":
+			body := `[]`
+			if listCalls < len(listed) {
+				body = listed[listCalls]
+			}
+			listCalls++
+			fmt.Fprint(w, body)
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/contacts/abc":
+			fmt.Fprint(w, `{"contactId": "abc", "email": "foo@bar.baz", "tags": [{"tagId": "t1"}], "customFieldValues": [{"customFieldId": "cf1", "value": ["old"]}, {"customFieldId": "cf2", "value": ["kept"]}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/contacts":
+			*creates++
+			w.WriteHeader(createStatus)
+			if createStatus == http.StatusConflict {
+				fmt.Fprint(w, `{"httpStatus": 409, "code": 1008}`)
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/contacts/abc":
+			update := Contact{}
+			json.NewDecoder(r.Body).Decode(&update)
+			*updates = append(*updates, update)
+			fmt.Fprint(w, `{"contactId": "abc", "email": "foo@bar.baz"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code": 1013}`)
+		}
+	}
+}
+
+func TestUnit_UpsertContact(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		listed           []string
+		createStatus     int
+		expectedCreates  int
+		expectedUpdates  []Contact
+		expectedErrCode  *string
+		expectedResponse Contact
+	}
+
+	found := `[{"contactId": "abc", "email": "FOO@bar.baz"}]`
+	mergedUpdate := Contact{
+		Name:              makeStringPtr("foobar"),
+		CustomFieldValues: []CustomField{CustomField{CustomFieldID: "cf1", Value: []string{"new"}}, CustomField{CustomFieldID: "cf2", Value: []string{"kept"}}, CustomField{CustomFieldID: "cf3", Value: []string{"added"}}},
+		Tags:              []Tag{Tag{TagID: "t1"}, Tag{TagID: "t2"}},
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:             "existing contact is merged",
+			listed:           []string{found},
+			expectedUpdates:  []Contact{mergedUpdate},
+			expectedResponse: Contact{ContactID: makeStringPtr("abc"), Email: makeStringPtr("foo@bar.baz")},
+		},
+		testcase{
+			name:             "partial email match is ignored",
+			listed:           []string{`[{"contactId": "zzz", "email": "xfoo@bar.baz"}]`, found},
+			createStatus:     http.StatusAccepted,
+			expectedCreates:  1,
+			expectedUpdates:  []Contact{},
+			expectedResponse: Contact{ContactID: makeStringPtr("abc"), Email: makeStringPtr("FOO@bar.baz")},
+		},
+		testcase{
+			name:             "missing contact is created and waited for",
+			listed:           []string{`[]`, `[]`, `[]`, found},
+			createStatus:     http.StatusAccepted,
+			expectedCreates:  1,
+			expectedUpdates:  []Contact{},
+			expectedResponse: Contact{ContactID: makeStringPtr("abc"), Email: makeStringPtr("FOO@bar.baz")},
+		},
+		testcase{
+			name:            "missing contact never shows up",
+			createStatus:    http.StatusAccepted,
+			expectedCreates: 1,
+			expectedUpdates: []Contact{},
+			expectedErrCode: makeStringPtr(ErrorTimeout),
+		},
+		testcase{
+			name:             "creation race falls back to update",
+			listed:           []string{`[]`, found},
+			createStatus:     http.StatusConflict,
+			expectedCreates:  1,
+			expectedUpdates:  []Contact{mergedUpdate},
+			expectedResponse: Contact{ContactID: makeStringPtr("abc"), Email: makeStringPtr("foo@bar.baz")},
+		},
+		testcase{
+			name:             "duplicate listed once created",
+			listed:           []string{`[]`, `[]`, `[]`, found},
+			createStatus:     http.StatusConflict,
+			expectedCreates:  1,
+			expectedUpdates:  []Contact{mergedUpdate},
+			expectedResponse: Contact{ContactID: makeStringPtr("abc"), Email: makeStringPtr("foo@bar.baz")},
+		},
+		testcase{
+			name:            "duplicate never listed",
+			createStatus:    http.StatusConflict,
+			expectedCreates: 1,
+			expectedUpdates: []Contact{},
+			expectedErrCode: makeStringPtr("1008"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			updates := []Contact{}
+			creates := 0
+			ts := httptest.NewServer(upsertHandler(tc.listed, tc.createStatus, &updates, &creates))
+			defer ts.Close()
+			c := NewClientWithOptions(WithBaseURL(ts.URL), WithPollPolicy(testPollPolicy))
+
+			ret, err := c.UpsertContact(context.Background(), "foo@bar.baz", makeStringPtr("foobar"), nil, "V",
+				[]CustomField{CustomField{CustomFieldID: "cf1", Value: []string{"new"}}, CustomField{CustomFieldID: "cf3", Value: []string{"added"}}},
+				[]Tag{Tag{TagID: "t2"}}, nil)
+			if creates != tc.expectedCreates {
+				t.Fatalf("Actual creates (%d) did not match expected (%d)", creates, tc.expectedCreates)
+			}
+			if !reflect.DeepEqual(tc.expectedUpdates, updates) {
+				t.Fatalf("Actual updates (%#v) did not match expected (%#v)", updates, tc.expectedUpdates)
+			}
+			if err == nil && tc.expectedErrCode == nil {
+				if !reflect.DeepEqual(tc.expectedResponse, ret) {
+					t.Fatalf("Actual response (%#v) did not match expected (%#v)", ret, tc.expectedResponse)
+				}
+			} else {
+				if tc.expectedErrCode == nil {
+					t.Fatalf("Unexpected error occurred (%#v)", err)
+				}
+				if err == nil {
+					t.Fatalf("Expected error did not occur")
+				}
+				if err.Code() != *tc.expectedErrCode {
+					t.Fatalf("Actual error (%#v) did not match expected (%#v)", err.Code(), *tc.expectedErrCode)
+				}
+			}
+		})
+	}
+}